@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+variable "organization_id" {
+  type = string
+}
+
+variable "asn_pool_id" {
+  type = string
+}
+
+resource "infrahub_autonomous_system" "core" {
+  name            = "AS65000"
+  asn             = 65000
+  organization_id = var.organization_id
+}
+
+# The ASN is allocated from the pool once and kept afterwards
+resource "infrahub_autonomous_system" "edge" {
+  name            = "EDGE"
+  asn_pool_id     = var.asn_pool_id
+  organization_id = var.organization_id
+}
+
+output "edge_asn" {
+  value = infrahub_autonomous_system.edge.asn
+}
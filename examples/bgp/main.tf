@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+variable "device_id" {
+  type = string
+}
+
+variable "local_as_id" {
+  type = string
+}
+
+variable "remote_as_id" {
+  type = string
+}
+
+resource "infrahub_ip_address" "local" {
+  address = "10.1.0.0/31"
+}
+
+resource "infrahub_ip_address" "remote" {
+  address = "10.1.0.1/31"
+}
+
+resource "infrahub_bgp_peer_group" "underlay" {
+  name           = "UNDERLAY"
+  local_as_id    = var.local_as_id
+  maximum_routes = 12000
+  send_community = true
+}
+
+resource "infrahub_bgp_session" "spine1" {
+  session_type  = "EXTERNAL"
+  status        = "active"
+  role          = "backbone"
+  device_id     = var.device_id
+  local_as_id   = var.local_as_id
+  remote_as_id  = var.remote_as_id
+  local_ip_id   = infrahub_ip_address.local.id
+  remote_ip_id  = infrahub_ip_address.remote.id
+  peer_group_id = infrahub_bgp_peer_group.underlay.id
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+variable "site_a_id" {
+  type = string
+}
+
+variable "site_z_id" {
+  type = string
+}
+
+resource "infrahub_provider_organization" "carrier" {
+  name = "Carrier Inc."
+}
+
+resource "infrahub_circuit_type" "dia" {
+  name        = "DIA"
+  description = "Dedicated internet access"
+}
+
+resource "infrahub_circuit" "wan" {
+  circuit_id      = "CXN-1042"
+  vendor_id       = "CAR-88213"
+  status          = "active"
+  role            = "backbone"
+  provider_id     = infrahub_provider_organization.carrier.id
+  circuit_type_id = infrahub_circuit_type.dia.id
+
+  endpoints = [
+    { location_id = var.site_a_id },
+    { location_id = var.site_z_id },
+  ]
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+# Devices edited in the UI between plan and apply are overwritten with a
+# warning instead of failing the apply
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+  conflict_mode   = "warn"
+}
+
+resource "infrahub_device" "leaf1" {
+  name_value          = "fra05-pod1-leaf1"
+  role_value          = "leaf"
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+}
+
+output "leaf1_updated_at" {
+  value = infrahub_device.leaf1.updated_at
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# The device was created by hand before Terraform managed it, take it over
+# on the first apply instead of failing because the name is taken
+resource "infrahub_device" "leaf1" {
+  name_value          = "fra05-pod1-leaf1"
+  role_value          = "leaf"
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+
+  create_or_adopt = true
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+resource "infrahub_manufacturer" "arista" {
+  name = "Arista"
+}
+
+resource "infrahub_platform" "eos" {
+  name                = "Arista EOS"
+  nornir_platform     = "eos"
+  napalm_driver       = "eos"
+  netmiko_device_type = "arista_eos"
+  ansible_network_os  = "arista.eos.eos"
+  containerlab_os     = "ceos"
+  manufacturer_id     = infrahub_manufacturer.arista.id
+}
+
+resource "infrahub_device_type" "dcs_7280" {
+  name            = "DCS-7280R"
+  part_number     = "DCS-7280SR-48C6"
+  height          = 1
+  full_depth      = true
+  manufacturer_id = infrahub_manufacturer.arista.id
+  platform_id     = infrahub_platform.eos.id
+}
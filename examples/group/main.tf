@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+resource "infrahub_group" "leafs" {
+  name        = "leafs"
+  label       = "Leaf switches"
+  description = "Targets of the leaf config generator"
+}
+
+resource "infrahub_group" "maintenance" {
+  name = "maintenance"
+}
+
+# Authoritative, the group holds exactly these members
+resource "infrahub_group_members" "leafs" {
+  group_id = infrahub_group.leafs.id
+  members = [
+    "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a",
+    "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b",
+  ]
+}
+
+# Non-authoritative, other configurations can add members to the same group
+resource "infrahub_group_member" "leaf1_maintenance" {
+  group_id  = infrahub_group.maintenance.id
+  member_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+}
@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+variable "device_id" {
+  type = string
+}
+
+resource "infrahub_ip_address" "loopback" {
+  address = "10.255.0.1/32"
+}
+
+resource "infrahub_interface_l3" "loopback0" {
+  name         = "Loopback0"
+  device_id    = var.device_id
+  speed        = 1000
+  enabled      = true
+  role         = "loopback"
+  ip_addresses = [infrahub_ip_address.loopback.id]
+}
+
+resource "infrahub_interface_l2" "server" {
+  name      = "Ethernet10"
+  device_id = var.device_id
+  speed     = 10000
+  mtu       = 9214
+  l2_mode   = "Access"
+  role      = "server"
+}
+
+# Existing interfaces can be imported with
+# terraform import infrahub_interface_l2.server <device_id>/Ethernet10
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+data "infrahub_next_available_ip" "mgmt" {
+  prefix = "10.0.0.0/24"
+}
+
+resource "infrahub_ip_address" "leaf1_mgmt" {
+  address     = data.infrahub_next_available_ip.mgmt.next_address
+  description = "fra05-pod1-leaf1 management"
+
+  lifecycle {
+    ignore_changes = [address]
+  }
+}
+
+# Existing addresses can be imported with
+# terraform import infrahub_ip_address.leaf1_mgmt default/10.0.0.1/24
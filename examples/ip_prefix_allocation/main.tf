@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+resource "infrahub_ip_prefix_allocation" "pod_loopbacks" {
+  pool_id       = "17f3b4f1-4c1e-2f5a-3b9d-c51b9b3f0a11"
+  identifier    = "fra05-pod1-loopbacks"
+  prefix_length = 24
+  member_type   = "address"
+}
+
+resource "infrahub_ip_prefix_allocation" "leaf1_spine1_p2p" {
+  pool_id       = "17f3b4f1-4c1e-2f5a-3b9d-c51b9b3f0a12"
+  identifier    = "fra05-pod1-leaf1-spine1"
+  prefix_length = 31
+}
+
+output "pod_loopbacks_prefix" {
+  value = infrahub_ip_prefix_allocation.pod_loopbacks.prefix
+}
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+# Attributes written by Terraform are recorded with the automation account
+# as source and the network team as owner
+provider "infrahub" {
+  api_key           = "XXX"
+  infrahub_server   = "10.0.0.1"
+  source_account_id = "17fc2f6f-1c5e-4e7a-3fb4-c51e3e1a7c1d"
+  owner_id          = "17fc2f6f-2d6f-4f8b-3fb5-c51f4f2b8d2e"
+}
+
+resource "infrahub_device" "leaf1" {
+  name_value          = "fra05-pod1-leaf1"
+  role_value          = "leaf"
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+
+  # Keep UI users from editing Terraform owned fields
+  protect_attributes = true
+}
+
+# Shows who last took over an attribute
+output "leaf1_attribute_owners" {
+  value = infrahub_device.leaf1.attribute_owners
+}
@@ -0,0 +1,64 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+resource "infrahub_continent" "europe" {
+  name      = "Europe"
+  shortname = "eu"
+}
+
+resource "infrahub_country" "france" {
+  name      = "France"
+  shortname = "fr"
+  parent_id = infrahub_continent.europe.id
+}
+
+resource "infrahub_metro" "paris" {
+  name      = "Paris"
+  shortname = "par"
+  timezone  = "Europe/Paris"
+  parent_id = infrahub_country.france.id
+}
+
+resource "infrahub_building" "par1" {
+  name        = "PAR-1"
+  shortname   = "par1"
+  facility_id = "EQX-PA2"
+  parent_id   = infrahub_metro.paris.id
+}
+
+resource "infrahub_floor" "par1_f1" {
+  name      = "PAR-1 Floor 1"
+  shortname = "par1-f1"
+  parent_id = infrahub_building.par1.id
+}
+
+resource "infrahub_suite" "par1_s101" {
+  name      = "PAR-1 Suite 101"
+  shortname = "par1-s101"
+  parent_id = infrahub_floor.par1_f1.id
+}
+
+resource "infrahub_rack" "par1_r01" {
+  name      = "PAR-1 R01"
+  shortname = "par1-r01"
+  parent_id = infrahub_suite.par1_s101.id
+}
+
+data "infrahub_location_tree" "rack" {
+  id = infrahub_rack.par1_r01.id
+}
+
+output "metro" {
+  value = [for l in data.infrahub_location_tree.rack.ancestors : l.name if l.kind == "LocationMetro"]
+}
@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# A single node, by human friendly ID or by filters
+data "infrahub_node" "web_servers" {
+  kind = "SecurityAddressGroup"
+  hfid = ["web-servers"]
+}
+
+data "infrahub_node" "web01" {
+  kind = "SecurityIPAddress"
+  filters = {
+    name__value = "web01"
+  }
+}
+
+# Every address of the group, read 50 at a time
+data "infrahub_nodes" "web_addresses" {
+  kind      = "SecurityIPAddress"
+  page_size = 50
+  filters = {
+    address_groups__ids = data.infrahub_node.web_servers.id
+  }
+}
+
+output "web01_address" {
+  value = data.infrahub_node.web01.attributes.address
+}
+
+output "web_addresses" {
+  value = [for node in data.infrahub_nodes.web_addresses.nodes : node.attributes.address]
+}
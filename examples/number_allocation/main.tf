@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# The pool is bound to InfraAutonomousSystem.asn, the other required
+# attributes of the autonomous system are passed in attributes.
+resource "infrahub_number_allocation" "fra05_pod1_asn" {
+  pool_id    = "17f3b4f1-4c1e-2f5a-3b9d-c51b9b3f0a21"
+  identifier = "fra05-pod1"
+  attributes = {
+    name = "fra05-pod1"
+  }
+}
+
+data "infrahub_pool_utilization" "private_asns" {
+  pool_id = infrahub_number_allocation.fra05_pod1_asn.pool_id
+}
+
+output "fra05_pod1_asn" {
+  value = infrahub_number_allocation.fra05_pod1_asn.number
+}
+
+output "private_asns_utilization" {
+  value = data.infrahub_pool_utilization.private_asns.utilization
+}
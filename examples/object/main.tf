@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# Kinds without a dedicated resource are managed by name
+resource "infrahub_object" "web_servers" {
+  kind = "SecurityAddressGroup"
+  attributes = {
+    name        = "web-servers"
+    description = "Servers reachable from the internet"
+  }
+}
+
+resource "infrahub_object" "web01" {
+  kind = "SecurityIPAddress"
+  attributes = {
+    name    = "web01"
+    address = "203.0.113.10/32"
+  }
+  relationships = {
+    address_groups = [infrahub_object.web_servers.id]
+  }
+}
+
+output "web01" {
+  value = infrahub_object.web01.display_label
+}
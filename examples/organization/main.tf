@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+resource "infrahub_manufacturer" "arista" {
+  name = "Arista"
+}
+
+resource "infrahub_provider_organization" "carrier" {
+  name        = "Carrier Inc."
+  description = "Transit and DIA"
+}
+
+resource "infrahub_tenant" "acme" {
+  name = "ACME"
+}
+
+data "infrahub_manufacturer" "juniper" {
+  name = "Juniper"
+}
+
+output "juniper_id" {
+  value = data.infrahub_manufacturer.juniper.id
+}
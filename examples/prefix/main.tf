@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+data "infrahub_prefix" "fabric" {
+  prefix = "10.0.0.0/16"
+}
+
+resource "infrahub_prefix" "pod1" {
+  prefix      = cidrsubnet(data.infrahub_prefix.fabric.prefix, 8, 1)
+  description = "fra05-pod1"
+  member_type = "address"
+  status      = "active"
+}
+
+output "fabric_children" {
+  value = data.infrahub_prefix.fabric.children_ids
+}
+
+# Existing prefixes can be imported with
+# terraform import infrahub_prefix.pod1 default/10.0.1.0/24
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# The device is managed by another configuration, only the edge below is
+# managed here
+variable "device_id" {
+  type = string
+}
+
+resource "infrahub_tag" "monitored" {
+  name = "monitored"
+}
+
+resource "infrahub_relationship" "device_monitored" {
+  node_id           = var.device_id
+  relationship_name = "tags"
+  peer_ids          = [infrahub_tag.monitored.id]
+}
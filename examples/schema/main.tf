@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# Incompatible changes to the documents fail the plan
+resource "infrahub_schema" "network" {
+  schemas = [
+    file("${path.module}/network.yml"),
+  ]
+}
+
+output "schema_hash" {
+  value = infrahub_schema.network.hash
+}
@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+# Typos in dropdown values such as role or status fail the plan instead of
+# the apply, custom choices added to the schema are accepted
+provider "infrahub" {
+  api_key              = "XXX"
+  infrahub_server      = "10.0.0.1"
+  fetch_schema_choices = true
+}
+
+resource "infrahub_device" "leaf1" {
+  name_value          = "fra05-pod1-leaf1"
+  role_value          = "leaf"
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+}
+
+# A choice added in the same run is accepted when the value refers to it
+resource "infrahub_schema_dropdown_choice" "border_leaf" {
+  kind      = "InfraDevice"
+  attribute = "role"
+  name      = "border_leaf"
+  label     = "Border Leaf"
+}
+
+resource "infrahub_device" "border1" {
+  name_value          = "fra05-pod1-border1"
+  role_value          = infrahub_schema_dropdown_choice.border_leaf.name
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+}
@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+# Custom device roles, reviewed like any other change
+resource "infrahub_schema_dropdown_choice" "border_leaf" {
+  kind        = "InfraDevice"
+  attribute   = "role"
+  name        = "border-leaf"
+  label       = "Border Leaf"
+  color       = "#7f7fff"
+  description = "Leaf connecting the fabric to the WAN"
+}
+
+resource "infrahub_schema_dropdown_choice" "oob" {
+  kind      = "InfraDevice"
+  attribute = "role"
+  name      = "oob"
+  label     = "Out of Band"
+}
+
+resource "infrahub_device" "bleaf1" {
+  name_value          = "fra05-pod1-bleaf1"
+  role_value          = infrahub_schema_dropdown_choice.border_leaf.name
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+}
@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+# Tags used in default_tags must already exist, the provider configuration
+# cannot depend on resources it manages.
+variable "managed_by_terraform_tag_id" {
+  type = string
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+  default_tags    = [var.managed_by_terraform_tag_id]
+}
+
+resource "infrahub_tag" "production" {
+  name        = "production"
+  description = "Serves production traffic"
+}
+
+resource "infrahub_device" "leaf1" {
+  name_value          = "fra05-pod1-leaf1"
+  role_value          = "leaf"
+  status_value        = "active"
+  device_type_node_id = "17fc2f6f-6e5b-4ba3-3fb2-c51c0b1e0b0a"
+  location_node_id    = "17fc2f6f-0f2f-45c5-3fb0-c51a2c0d5a5b"
+  tags                = [infrahub_tag.production.id]
+}
+
+# tags holds the production tag, tags_all also holds the default tag
+output "leaf1_tags_all" {
+  value = infrahub_device.leaf1.tags_all
+}
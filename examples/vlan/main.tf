@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+variable "location_id" {
+  type = string
+}
+
+resource "infrahub_vlan" "server" {
+  name        = "fra05-server"
+  vlan_id     = 100
+  status      = "active"
+  role        = "server"
+  location_id = var.location_id
+}
+
+data "infrahub_vlans" "fra05" {
+  location_id = var.location_id
+  status      = "active"
+}
+
+output "fra05_vlan_ids" {
+  value = [for vlan in data.infrahub_vlans.fra05.vlans : vlan.vlan_id]
+}
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    infrahub = {
+      source  = "registry.terraform.io/marcom4rtinez/infrahub"
+      version = "1.0"
+    }
+  }
+}
+
+provider "infrahub" {
+  api_key         = "XXX"
+  infrahub_server = "10.0.0.1"
+}
+
+resource "infrahub_route_target" "tenant_a" {
+  name        = "65000:100"
+  description = "Tenant A"
+}
+
+resource "infrahub_vrf" "tenant_a" {
+  name      = "tenant-a"
+  vrf_rd    = "65000:100"
+  import_rt = [infrahub_route_target.tenant_a.id]
+  export_rt = [infrahub_route_target.tenant_a.id]
+}
+
+data "infrahub_vrf_prefixes" "tenant_a" {
+  vrf_id = infrahub_vrf.tenant_a.id
+}
+
+output "tenant_a_prefixes" {
+  value = [for prefix in data.infrahub_vrf_prefixes.tenant_a.prefixes : prefix.prefix]
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -60,10 +61,11 @@ func ParseGraphQLQuery(query string) (*InputGraphQLQuery, error) {
 
 func parseResourceInput(lines []string) (InputGraphQLQuery, error) {
 	var queryName, required, objectName, parentPrefix string
-	var inBlock bool
+	var inBlock, taggable, versioned bool
+	var skipDepth int
 	var prefixList, prefixListImmutable []string
 	var fields []Field
-	var genqlientFields, genqlientFieldsModify, genqlientFieldsReadOnly []GenqlientField
+	var genqlientFields, genqlientFieldsModify, genqlientFieldsReadOnly, metadataFields []GenqlientField
 
 	index := 0
 	for number, line := range lines {
@@ -98,6 +100,37 @@ func parseResourceInput(lines []string) (InputGraphQLQuery, error) {
 	for number, line := range lines[index:] {
 
 		line = strings.TrimSpace(line)
+		// tags are a set of IDs handled by the template, skip the whole block
+		if skipDepth > 0 {
+			if strings.HasSuffix(line, " {") {
+				skipDepth++
+			} else if line == "}" {
+				skipDepth--
+			}
+			continue
+		}
+		if line == "tags {" {
+			taggable = true
+			skipDepth = 1
+			continue
+		}
+		// the node timestamp is checked by the template before updates
+		if line == "updated_at: _updated_at" {
+			versioned = true
+			continue
+		}
+		// owner and source of an attribute are read into the lineage maps
+		if inBlock && (line == "owner {" || line == "source {") {
+			metadata := strings.TrimSuffix(line, " {")
+			fields = append(fields, Field{
+				Name:     parentPrefix + metadata,
+				Type:     "String",
+				Metadata: metadata,
+			})
+			skipDepth = 1
+			continue
+		}
+
 		if strings.HasPrefix(line, "query ") || number == 1 {
 		} else if strings.HasSuffix(line, " {") {
 			inBlock = true
@@ -194,13 +227,23 @@ func parseResourceInput(lines []string) (InputGraphQLQuery, error) {
 
 		newField := GenqlientField{
 			Field: Field{
-				Name: entry.Name,
-				Type: entry.Type,
+				Name:     entry.Name,
+				Type:     entry.Type,
+				Metadata: entry.Metadata,
 			},
 			Query:                  objectName + "." + strings.Join(parts, "."),
 			QueryNoPrefixReplaceId: strings.Join(noPrefix, "."),
 			InputObjectNames:       strings.Join(filtered, "."),
 			PlainObject:            strings.Join(plain[2:], "."),
+			InputObject:            strings.Join(filtered[:len(filtered)-1], "."),
+			Relationship:           filtered[len(filtered)-1] == "Id",
+		}
+
+		if entry.Metadata != "" {
+			// Named after the value attribute, e.g. name_value
+			newField.HumanReadableName = strings.TrimSuffix(strings.ReplaceAll(entry.Name, "edges_node_", ""), entry.Metadata) + "value"
+			metadataFields = append(metadataFields, newField)
+			continue
 		}
 
 		if strings.Count(strings.ToLower(newField.Query), "node") < 2 && strings.Count(strings.ToLower(newField.Query), "id") < 1 {
@@ -228,6 +271,9 @@ func parseResourceInput(lines []string) (InputGraphQLQuery, error) {
 		GenqlientFields:         genqlientFields,
 		genqlientFieldsReadOnly: genqlientFieldsReadOnly,
 		genqlientFieldsModify:   genqlientFieldsModify,
+		Taggable:                taggable,
+		Versioned:               versioned,
+		MetadataFields:          metadataFields,
 	}, nil
 }
 
@@ -373,3 +419,94 @@ func addHumanReadableField(fields []GenqlientField) {
 		fields[i].HumanReadableName = strings.ReplaceAll(field.Name, "edges_node_", "")
 	}
 }
+
+// UpdateMutation derives the Update mutation of a resource from its Upsert
+// mutation. Both take the same input and return the same object, the Update
+// mutation only fails instead of creating the object when it does not exist.
+func UpdateMutation(query string, queryName string) (string, error) {
+	upsert := "mutation " + strings.ToUpper(queryName[:1]) + queryName[1:] + "Upsert("
+	lines := operationLines(query, upsert)
+	if len(lines) == 0 {
+		return "", fmt.Errorf("failed to derive update mutation: missing %s", strings.TrimSuffix(upsert, "("))
+	}
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "Upsert", "Update")
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// ByIdQuery derives the query reading a resource by ID from its read query,
+// which looks the object up by its required attribute. The conflict check
+// reads the object by the ID in the state, so that a rename between plan and
+// apply does not hide it.
+func ByIdQuery(query string, queryName string) (string, error) {
+	name := strings.ToUpper(queryName[:1]) + queryName[1:]
+	lines := operationLines(query, "query "+name+"(")
+	if len(lines) < 2 {
+		return "", fmt.Errorf("failed to derive query by ID: missing query %s", name)
+	}
+	start, end := strings.Index(lines[1], "("), strings.LastIndex(lines[1], ")")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("failed to derive query by ID: query %s has no filter", name)
+	}
+	lines[0] = "query " + name + "ById($id: ID!) {"
+	lines[1] = lines[1][:start] + "(ids: [$id])" + lines[1][end+1:]
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// operationLines returns the lines of the operation of query starting with
+// prefix, up to its closing brace.
+func operationLines(query string, prefix string) []string {
+	var lines []string
+	depth := 0
+	for _, line := range strings.Split(query, "\n") {
+		if len(lines) == 0 && !strings.HasPrefix(strings.TrimSpace(line), prefix) {
+			continue
+		}
+		lines = append(lines, line)
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth == 0 && strings.Contains(line, "}") {
+			break
+		}
+	}
+	return lines
+}
+
+// MarkDropdowns sets Dropdown on the fields holding the value of a Dropdown
+// attribute of objectName in the GraphQL schema. The schema only has the
+// attribute types, the choices are read from the server by the provider.
+func MarkDropdowns(schemaPath string, objectName string, fields []GenqlientField) ([]GenqlientField, error) {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]string{}
+	inType := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "type "+objectName+" ") {
+			inType = true
+			continue
+		}
+		if !inType {
+			continue
+		}
+		if line == "}" {
+			break
+		}
+		if name, fieldType, ok := strings.Cut(line, ": "); ok && !strings.Contains(name, "(") {
+			types[name] = strings.TrimSuffix(fieldType, "!")
+		}
+	}
+
+	var dropdowns []GenqlientField
+	for i, field := range fields {
+		attribute := strings.ToLower(field.InputObject)
+		if !field.Relationship && types[attribute] == "Dropdown" {
+			fields[i].Dropdown = attribute
+			dropdowns = append(dropdowns, fields[i])
+		}
+	}
+	return dropdowns, nil
+}
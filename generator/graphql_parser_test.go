@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestByIdQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		queryName string
+		want      string
+		wantError bool
+	}{
+		{
+			name: "filtered by an attribute",
+			query: `query Device($device_name: String!) {
+  InfraDevice(name__value: $device_name) {
+    edges {
+      node {
+        id
+      }
+    }
+  }
+}
+`,
+			queryName: "device",
+			want: `query DeviceById($id: ID!) {
+  InfraDevice(ids: [$id]) {
+    edges {
+      node {
+        id
+      }
+    }
+  }
+}
+`,
+		},
+		{
+			name: "among other operations",
+			query: `mutation DeviceDelete($id: String!) {
+  InfraDeviceDelete(data: {id: $id}) {
+    ok
+  }
+}
+query Device($device_name: String!) {
+  InfraDevice(name__value: $device_name) { edges { node { id } } }
+}
+query Devices {
+  InfraDevice { edges { node { id } } }
+}
+`,
+			queryName: "device",
+			want: `query DeviceById($id: ID!) {
+  InfraDevice(ids: [$id]) { edges { node { id } } }
+}
+`,
+		},
+		{
+			name: "without filter",
+			query: `query Device {
+  InfraDevice {
+    edges { node { id } }
+  }
+}
+`,
+			queryName: "device",
+			wantError: true,
+		},
+		{
+			name:      "missing query",
+			query:     "query Devices {\n  InfraDevice { edges { node { id } } }\n}\n",
+			queryName: "device",
+			wantError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ByIdQuery(test.query, test.queryName)
+			if (err != nil) != test.wantError {
+				t.Fatalf("ByIdQuery() error = %v, want error %t", err, test.wantError)
+			}
+			if got != test.want {
+				t.Errorf("ByIdQuery() =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUpdateMutation(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		queryName string
+		want      string
+		wantError bool
+	}{
+		{
+			name: "upsert among other operations",
+			query: `mutation DeviceCreate($data: InfraDeviceCreateInput!) {
+  InfraDeviceCreate(data: $data) {
+    object { id }
+  }
+}
+mutation DeviceUpsert($data: InfraDeviceUpsertInput!) {
+  InfraDeviceUpsert(data: $data) {
+    ok
+    object {
+      id
+      name { value }
+    }
+  }
+}
+query Device($device_name: String!) {
+  InfraDevice(name__value: $device_name) { edges { node { id } } }
+}
+`,
+			queryName: "device",
+			want: `mutation DeviceUpdate($data: InfraDeviceUpdateInput!) {
+  InfraDeviceUpdate(data: $data) {
+    ok
+    object {
+      id
+      name { value }
+    }
+  }
+}
+`,
+		},
+		{
+			name: "missing upsert",
+			query: `mutation DeviceCreate($data: InfraDeviceCreateInput!) {
+  InfraDeviceCreate(data: $data) { object { id } }
+}
+`,
+			queryName: "device",
+			wantError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := UpdateMutation(test.query, test.queryName)
+			if (err != nil) != test.wantError {
+				t.Fatalf("UpdateMutation() error = %v, want error %t", err, test.wantError)
+			}
+			if got != test.want {
+				t.Errorf("UpdateMutation() =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMarkDropdowns(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.graphql")
+	schema := `"""A device"""
+type InfraDevice implements CoreNode {
+  name: TextAttribute!
+  role: Dropdown
+  status: Dropdown!
+  location(id: ID): NestedEdgedLocationGeneric!
+  member_of_groups(offset: Int, limit: Int): NestedPaginatedCoreGroup!
+}
+
+type InfraDeviceType implements CoreNode {
+  name: TextAttribute!
+  platform: Dropdown
+}
+`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		objectName string
+		fields     []GenqlientField
+		want       []string
+	}{
+		{
+			name:       "attributes and relationships",
+			objectName: "InfraDevice",
+			fields: []GenqlientField{
+				{InputObject: "Name"},
+				{InputObject: "Role"},
+				{InputObject: "Status"},
+				{InputObject: "Location", Relationship: true},
+			},
+			want: []string{"", "role", "status", ""},
+		},
+		{
+			name:       "fields of another type",
+			objectName: "InfraDeviceType",
+			fields: []GenqlientField{
+				{InputObject: "Role"},
+				{InputObject: "Platform"},
+			},
+			want: []string{"", "platform"},
+		},
+		{
+			name:       "missing type",
+			objectName: "InfraCircuit",
+			fields:     []GenqlientField{{InputObject: "Status"}},
+			want:       []string{""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dropdowns, err := MarkDropdowns(schemaPath, test.objectName, test.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantDropdowns := 0
+			for i, field := range test.fields {
+				if field.Dropdown != test.want[i] {
+					t.Errorf("field %s: Dropdown = %q, want %q", field.InputObject, field.Dropdown, test.want[i])
+				}
+				if test.want[i] != "" {
+					wantDropdowns++
+				}
+			}
+			if len(dropdowns) != wantDropdowns {
+				t.Errorf("MarkDropdowns() returned %d dropdowns, want %d", len(dropdowns), wantDropdowns)
+			}
+		})
+	}
+
+	if _, err := MarkDropdowns(filepath.Join(t.TempDir(), "missing.graphql"), "InfraDevice", nil); err == nil {
+		t.Error("MarkDropdowns() on a missing schema: expected an error")
+	}
+}
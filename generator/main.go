@@ -45,6 +45,10 @@ func GenerateTerraformDataSource(parsedQuery *InputGraphQLQuery) (string, error)
 
 func GenerateTerraformResource(parsedQuery *InputGraphQLQuery) (string, error) {
 	structName := parsedQuery.QueryName + "Resource"
+	dropdowns, err := MarkDropdowns("../sdk/schema.graphql", parsedQuery.ObjectName, parsedQuery.genqlientFieldsModify)
+	if err != nil {
+		return "", err
+	}
 	data := ResourceTemplateData{
 		QueryName:               parsedQuery.QueryName,
 		ObjectName:              parsedQuery.ObjectName,
@@ -54,6 +58,10 @@ func GenerateTerraformResource(parsedQuery *InputGraphQLQuery) (string, error) {
 		GenqlientFields:         parsedQuery.GenqlientFields,
 		GenqlientFieldsModify:   parsedQuery.genqlientFieldsModify,
 		GenqlientFieldsReadOnly: parsedQuery.genqlientFieldsReadOnly,
+		Taggable:                parsedQuery.Taggable,
+		Versioned:               parsedQuery.Versioned,
+		MetadataFields:          parsedQuery.MetadataFields,
+		Dropdowns:               dropdowns,
 	}
 
 	// Render the template
@@ -74,10 +82,71 @@ func GenerateTerraformResource(parsedQuery *InputGraphQLQuery) (string, error) {
 	return buf.String(), nil
 }
 
+// generatedHeader marks the operations the generator writes into sdk/gql.
+const generatedHeader = "# Code generated by the generator from generator/gql. DO NOT EDIT.\n\n"
+
+// Constructors of resources and data sources that are written by hand in
+// internal/provider instead of being generated from a .gql file.
+var customResources = []string{
+	"NewIPPrefixAllocationResource",
+	"NewNumberAllocationResource",
+	"NewIPAddressResource",
+	"NewPrefixResource",
+	"NewVLANResource",
+	"NewVRFResource",
+	"NewRouteTargetResource",
+	"NewInterfaceL3Resource",
+	"NewInterfaceL2Resource",
+	"NewBGPSessionResource",
+	"NewBGPPeerGroupResource",
+	"NewAutonomousSystemResource",
+	"NewCircuitResource",
+	"NewCircuitTypeResource",
+	"NewManufacturerResource",
+	"NewProviderOrganizationResource",
+	"NewProviderOrgResource",
+	"NewTenantResource",
+	"NewDeviceTypeResource",
+	"NewPlatformResource",
+	"NewLocationContinentResource",
+	"NewLocationCountryResource",
+	"NewLocationMetroResource",
+	"NewLocationBuildingResource",
+	"NewLocationFloorResource",
+	"NewLocationSuiteResource",
+	"NewLocationRackResource",
+	"NewTagResource",
+	"NewGroupResource",
+	"NewGroupMembersResource",
+	"NewGroupMemberResource",
+	"NewRelationshipResource",
+	"NewSchemaDropdownChoiceResource",
+	"NewSchemaEnumValueResource",
+	"NewSchemaResource",
+	"NewObjectResource",
+}
+
+var customDataSources = []string{
+	"NewPoolUtilizationDataSource",
+	"NewNextAvailableIPDataSource",
+	"NewNextAvailablePrefixDataSource",
+	"NewPrefixDataSource",
+	"NewVLANsDataSource",
+	"NewVRFPrefixesDataSource",
+	"NewLocationTreeDataSource",
+	"NewManufacturerDataSource",
+	"NewProviderOrganizationDataSource",
+	"NewTenantDataSource",
+	"NewNodeDataSource",
+	"NewNodesDataSource",
+}
+
 func GenerateTerraformProvider(components TerraformComponents) (string, error) {
 	data := ProviderSourceTemplateData{
-		DataSources: components.dataSources,
-		Resources:   components.resources,
+		DataSources:       components.dataSources,
+		Resources:         components.resources,
+		CustomDataSources: customDataSources,
+		CustomResources:   customResources,
 	}
 
 	// Render the template
@@ -145,6 +214,25 @@ func readAndGenerateDataSourcesAndResources(graphqlQuery string) (string, string
 		}
 
 		fmt.Printf("Content written to %s_resource.go file successfully!\n", parsedQuery.QueryName)
+
+		// The SDK is generated after the provider, so it picks up the update
+		// mutation and the query by ID
+		update, err := UpdateMutation(graphqlQuery, parsedQuery.QueryName)
+		if err != nil {
+			return "", "", err
+		}
+		err = os.WriteFile(fmt.Sprintf("../sdk/gql/%s_update.gql", parsedQuery.QueryName), []byte(generatedHeader+update), 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("Error writing the update mutation: %s", err)
+		}
+		byId, err := ByIdQuery(graphqlQuery, parsedQuery.QueryName)
+		if err != nil {
+			return "", "", err
+		}
+		err = os.WriteFile(fmt.Sprintf("../sdk/gql/%s_by_id.gql", parsedQuery.QueryName), []byte(generatedHeader+byId), 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("Error writing the query by ID: %s", err)
+		}
 		return "", parsedQuery.QueryName, nil
 	}
 
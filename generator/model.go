@@ -20,12 +20,17 @@ type InputGraphQLQuery struct {
 	genqlientFieldsModify   []GenqlientField
 	genqlientFieldsReadOnly []GenqlientField
 	ResourceType            ResourceType
+	Taggable                bool
+	Versioned               bool
+	MetadataFields          []GenqlientField
 }
 
 type Field struct {
 	Name              string
 	HumanReadableName string
 	Type              string
+	// Metadata is "owner" or "source" for the lineage of an attribute.
+	Metadata string
 }
 
 type GenqlientField struct {
@@ -34,6 +39,11 @@ type GenqlientField struct {
 	QueryNoPrefixReplaceId string
 	InputObjectNames       string
 	PlainObject            string
+	// InputObject is the attribute or relationship input holding the field.
+	InputObject  string
+	Relationship bool
+	// Dropdown is the attribute name when the field holds a dropdown value.
+	Dropdown string
 }
 
 type DataSourceTemplateData struct {
@@ -54,11 +64,31 @@ type ResourceTemplateData struct {
 	GenqlientFields         []GenqlientField
 	GenqlientFieldsModify   []GenqlientField
 	GenqlientFieldsReadOnly []GenqlientField
+	Taggable                bool
+	Versioned               bool
+	MetadataFields          []GenqlientField
+	Dropdowns               []GenqlientField
 }
+
+// OperationTemplateData is the data of the templates shared by the mutations
+// of a resource. Op is the mutation, e.g. Create, and Var the input variable.
+type OperationTemplateData struct {
+	ResourceTemplateData
+	Op  string
+	Var string
+}
+
+// With returns the template data of a single mutation of the resource.
+func (d ResourceTemplateData) With(op string, variable string) OperationTemplateData {
+	return OperationTemplateData{ResourceTemplateData: d, Op: op, Var: variable}
+}
+
 type ProviderSourceTemplateData struct {
-	DataSources []string
-	Resources   []string
-	Functions   []string
+	DataSources       []string
+	Resources         []string
+	Functions         []string
+	CustomDataSources []string
+	CustomResources   []string
 }
 
 type TerraformComponents struct {
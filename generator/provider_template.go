@@ -12,12 +12,14 @@ import (
 	"os"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -37,6 +39,24 @@ type InfrahubProvider struct {
 type InfrahubProviderModel struct {
 	ApiKey         types.String ` + "`tfsdk:\"api_key\"`" + `
 	InfrahubServer types.String ` + "`tfsdk:\"infrahub_server\"`" + `
+	DefaultTags     types.Set    ` + "`tfsdk:\"default_tags\"`" + `
+	SourceAccountId types.String ` + "`tfsdk:\"source_account_id\"`" + `
+	OwnerId         types.String ` + "`tfsdk:\"owner_id\"`" + `
+	ConflictMode    types.String ` + "`tfsdk:\"conflict_mode\"`" + `
+	FetchChoices    types.Bool   ` + "`tfsdk:\"fetch_schema_choices\"`" + `
+}
+
+// infrahubClient is handed to resources and data sources as provider data.
+// It embeds the GraphQL client, so components asserting graphql.Client keep
+// working, and carries the provider wide settings.
+type infrahubClient struct {
+	graphql.Client
+	defaultTags     []string
+	sourceAccountId string
+	ownerId         string
+	conflictMode    string
+	choices         *schemaChoices
+	schema          schemaAPI
 }
 
 func New(version string) func() provider.Provider {
@@ -64,6 +84,35 @@ func (p *InfrahubProvider) Schema(ctx context.Context, req provider.SchemaReques
 				MarkdownDescription: "Infrahub Server running API",
 				Optional:            true,
 			},
+			"default_tags": schema.SetAttribute{
+				MarkdownDescription: "IDs of tags added to every taggable resource managed by the provider",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"source_account_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the account recorded as source of the attributes and relationships written by generated resources",
+				Optional:            true,
+			},
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the account or group recorded as owner of the attributes and relationships written by generated resources",
+				Optional:            true,
+			},
+			"conflict_mode": schema.StringAttribute{
+				MarkdownDescription: "What generated resources do when a node was modified in Infrahub since it was last read: " +
+					"` + "`error`" + ` (default) fails the update, ` + "`warn`" + ` overwrites the changes with a warning. " +
+					"Only the resources generated from ` + "`generator/gql`" + `, e.g. ` + "`infrahub_device`" + `, are checked",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(conflictModeError, conflictModeWarn),
+				},
+			},
+			"fetch_schema_choices": schema.BoolAttribute{
+				MarkdownDescription: "Read the dropdown choices and enum values from the schema of the server, so planned values of " +
+					"dropdown attributes are checked before apply. The GraphQL schema has no choices, so nothing is checked without it. " +
+					"Choices planned by ` + "`infrahub_schema_dropdown_choice`" + ` and ` + "`infrahub_schema_enum_value`" + ` are accepted when the " +
+					"value refers to them, and nothing is rejected while an ` + "`infrahub_schema`" + ` resource changes the schema",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -96,6 +145,28 @@ func (p *InfrahubProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if data.DefaultTags.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown default tags",
+			"The provider cannot read the default tags as there is an unknown configuration value. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if data.SourceAccountId.IsUnknown() || data.OwnerId.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown attribute lineage",
+			"The provider cannot read source_account_id or owner_id as there is an unknown configuration value. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	var defaultTags []string
+	if !data.DefaultTags.IsNull() && !data.DefaultTags.IsUnknown() {
+		resp.Diagnostics.Append(data.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+	}
+
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
@@ -137,18 +208,43 @@ func (p *InfrahubProvider) Configure(ctx context.Context, req provider.Configure
 		},
 	}
 
-	client := graphql.NewClient(fmt.Sprintf("http://%s:8000/graphql", infrahub_server), httpClient)
+	client := &infrahubClient{
+		Client:          graphql.NewClient(fmt.Sprintf("http://%s:8000/graphql", infrahub_server), httpClient),
+		defaultTags:     defaultTags,
+		sourceAccountId: data.SourceAccountId.ValueString(),
+		ownerId:         data.OwnerId.ValueString(),
+		conflictMode:    conflictModeError,
+		schema:          schemaAPI{client: httpClient, server: infrahub_server},
+	}
+	if !data.ConflictMode.IsNull() && !data.ConflictMode.IsUnknown() {
+		client.conflictMode = data.ConflictMode.ValueString()
+	}
+
+	if data.FetchChoices.ValueBool() && !resp.Diagnostics.HasError() {
+		choices, err := loadSchemaChoices(ctx, client.schema)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read the schema choices from Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		client.choices = choices
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
 func (p *InfrahubProvider) Resources(ctx context.Context) []func() resource.Resource {
-    {{- if .Resources }}
+    {{- if or .Resources .CustomResources }}
     return []func() resource.Resource{
         {{- range .Resources }}
         New{{ . | title }}Resource,
         {{- end }}
+        {{- range .CustomResources }}
+        {{ . }},
+        {{- end }}
     }
     {{- else }}
     return nil
@@ -156,11 +252,14 @@ func (p *InfrahubProvider) Resources(ctx context.Context) []func() resource.Reso
 }
 
 func (p *InfrahubProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-    {{- if .DataSources }}
+    {{- if or .DataSources .CustomDataSources }}
     return []func() datasource.DataSource{
         {{- range .DataSources }}
         New{{ . | title }}DataSource,
         {{- end }}
+        {{- range .CustomDataSources }}
+        {{ . }},
+        {{- end }}
     }
     {{- else }}
     return nil
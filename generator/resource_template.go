@@ -21,6 +21,9 @@ import (
 var (
 	_ resource.Resource              = &{{.QueryName}}Resource{}
 	_ resource.ResourceWithConfigure = &{{.QueryName}}Resource{}
+	{{- if or .Taggable .Dropdowns }}
+	_ resource.ResourceWithModifyPlan = &{{.QueryName}}Resource{}
+	{{- end }}
 )
 
 // New{{.QueryName | title }}Resource is a helper function to simplify the provider implementation.
@@ -31,9 +34,30 @@ func New{{.QueryName | title }}Resource() resource.Resource {
 // {{.QueryName }}Resource is the resource implementation.
 type {{.QueryName }}Resource struct {
 	client         *graphql.Client
+	lineage        lineage
+	{{- if .Taggable }}
+	defaultTags    []string
+	{{- end }}
+	{{- if .Versioned }}
+	conflictMode   string
+	{{- end }}
+	{{- if .Dropdowns }}
+	choices        *schemaChoices
+	{{- end }}
 	{{- range .GenqlientFields }}
 	{{ .Name | title }} types.String ` + "`tfsdk:\"{{ .HumanReadableName }}\"`" + `
 	{{- end }}
+	{{- if .Taggable }}
+	Tags    types.Set ` + "`tfsdk:\"tags\"`" + `
+	TagsAll types.Set ` + "`tfsdk:\"tags_all\"`" + `
+	{{- end }}
+	{{- if .Versioned }}
+	UpdatedAt types.String ` + "`tfsdk:\"updated_at\"`" + `
+	{{- end }}
+	CreateOrAdopt     types.Bool ` + "`tfsdk:\"create_or_adopt\"`" + `
+	ProtectAttributes types.Bool ` + "`tfsdk:\"protect_attributes\"`" + `
+	AttributeOwners   types.Map  ` + "`tfsdk:\"attribute_owners\"`" + `
+	AttributeSources  types.Map  ` + "`tfsdk:\"attribute_sources\"`" + `
 }
 
 // Metadata returns the resource type name.
@@ -63,8 +87,22 @@ func (r *{{.QueryName}}Resource) Schema(_ context.Context, _ resource.SchemaRequ
 					},
 				{{- end }}
 			{{- end }}
+			{{- if .Taggable }}
+			"tags":     tagsAttribute(),
+			"tags_all": tagsAllAttribute(),
+			{{- end }}
+			{{- if .Versioned }}
+			"updated_at": updatedAtAttribute(),
+			{{- end }}
+			"create_or_adopt": schema.BoolAttribute{
+				MarkdownDescription: "Take over an existing {{ .QueryName }} with the same human friendly ID instead of failing on create",
+				Optional:            true,
+			},
 		},
 	}
+	for name, attribute := range lineageAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -86,24 +124,48 @@ func (r *{{.QueryName}}Resource) Create(ctx context.Context, req resource.Create
 	{{- range .GenqlientFieldsModify }}
 	default{{$defaultCreate}}.{{ .InputObjectNames }} = plan.{{ .Name | title }}.ValueString()
 	{{- end }}
+	{{- if .Taggable }}
+	default{{$defaultCreate}}.Tags = relatedNodeInputs(mergeTags(plan.Tags, r.defaultTags))
+	{{- end }}
+
+	{{- template "lineage" .With "" (printf "default%s" $defaultCreate) }}
 
 	tflog.Info(ctx, fmt.Sprint("Creating {{ .QueryName | title }} ", plan.{{.Required | title }}))
 
-	response, err := infrahub_sdk.{{ .QueryName | title }}Create(ctx, *r.client, default{{ .QueryName | title }})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create {{ .QueryName }} in Infrahub",
-			err.Error(),
-		)
-		return
+	if plan.CreateOrAdopt.ValueBool() {
+		// Upsert takes over an existing {{ .QueryName }} with the same human friendly ID
+		var adoptInput infrahub_sdk.{{ .ObjectName }}UpsertInput
+		{{- range .GenqlientFieldsModify }}
+		adoptInput.{{ .InputObjectNames }} = plan.{{ .Name | title }}.ValueString()
+		{{- end }}
+		{{- if .Taggable }}
+		adoptInput.Tags = relatedNodeInputs(mergeTags(plan.Tags, r.defaultTags))
+		{{- end }}
+		{{- template "lineage" .With "" "adoptInput" }}
+
+		response, err := infrahub_sdk.{{ .QueryName | title }}Upsert(ctx, *r.client, adoptInput)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create or adopt {{ .QueryName }} in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+
+		{{- template "response" .With "Upsert" "" }}
+	} else {
+		response, err := infrahub_sdk.{{ .QueryName | title }}Create(ctx, *r.client, default{{ .QueryName | title }})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create {{ .QueryName }} in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+
+		{{- template "response" .With "Create" "" }}
 	}
 
-	{{- $defaultCreateObject :=  .ObjectName }}
-	{{- range .GenqlientFields }}
-	plan.{{ .Name | title }} = types.StringValue(response.{{ $defaultCreateObject }}Create.Object.{{ .PlainObject }})
-	{{- end }}
-
-
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -150,6 +212,32 @@ func (r *{{.QueryName}}Resource) Read(ctx context.Context, req resource.ReadRequ
 	{{- range .GenqlientFields }}
 	state.{{ .Name | title }} = types.StringValue(response.{{ .Query }})
 	{{- end }}
+	{{- if .Taggable }}
+
+	tags := []string{}
+	for _, edge := range response.{{ .ObjectName }}.Edges[0].Node.Tags.Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+	state.Tags = resourceTags(tags, state.Tags, r.defaultTags)
+	state.TagsAll = idSet(tags...)
+	{{- end }}
+	{{- if .Versioned }}
+	state.UpdatedAt = updatedAt(response.{{ .ObjectName }}.Edges[0].Node.Updated_at)
+	{{- end }}
+	state.AttributeOwners = lineageMap(map[string]string{
+		{{- range .MetadataFields }}
+		{{- if eq .Metadata "owner" }}
+		"{{ .HumanReadableName }}": nodeId(response.{{ .Query }}),
+		{{- end }}
+		{{- end }}
+	})
+	state.AttributeSources = lineageMap(map[string]string{
+		{{- range .MetadataFields }}
+		{{- if eq .Metadata "source" }}
+		"{{ .HumanReadableName }}": nodeId(response.{{ .Query }}),
+		{{- end }}
+		{{- end }}
+	})
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -175,8 +263,38 @@ func (r *{{.QueryName}}Resource) Update(ctx context.Context, req resource.Update
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	{{- if .Versioned }}
 
-	var updateInput infrahub_sdk.{{ .ObjectName }}UpsertInput
+	// Refuse to overwrite changes made in Infrahub since the plan was made.
+	// The node is read by ID, so that a rename does not hide the changes.
+	current, err := infrahub_sdk.{{ .QueryName | title }}ById(ctx, *r.client, state.{{ (index .GenqlientFieldsReadOnly 0).Name | title }}.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read {{ .QueryName }} from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	if len(current.{{ .ObjectName }}.Edges) == 0 {
+		resp.Diagnostics.AddError(
+			"{{ .ObjectName }} no longer exists in Infrahub",
+			fmt.Sprintf("{{ .ObjectName }} %s was deleted in Infrahub after the plan was made. Run terraform plan again to create it.", state.{{ (index .GenqlientFieldsReadOnly 0).Name | title }}.ValueString()),
+		)
+		return
+	}
+	changed := []string{}
+	{{- range .GenqlientFieldsModify }}
+	if current.{{ .Query }} != state.{{ .Name | title }}.ValueString() {
+		changed = append(changed, "{{ .HumanReadableName }}")
+	}
+	{{- end }}
+	resp.Diagnostics.Append(checkConflict(r.conflictMode, "{{ .ObjectName }}", state.{{ (index .GenqlientFieldsReadOnly 0).Name | title }}.ValueString(), state.UpdatedAt, current.{{ .ObjectName }}.Edges[0].Node.Updated_at, changed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	{{- end }}
+
+	var updateInput infrahub_sdk.{{ .ObjectName }}UpdateInput
 
 	// Prepare the update input using values from the plan and applying defaults
 	{{- range .GenqlientFieldsModify }}
@@ -184,13 +302,17 @@ func (r *{{.QueryName}}Resource) Update(ctx context.Context, req resource.Update
 	{{- end }}
 	{{- $idElement :=  (index .GenqlientFieldsReadOnly 0).Name | title  }}
 	updateInput.Id = state.{{$idElement}}.ValueString()
+	{{- if .Taggable }}
+	updateInput.Tags = relatedNodeInputs(mergeTags(plan.Tags, r.defaultTags))
+	{{- end }}
 
+	{{- template "lineage" .With "" "updateInput" }}
 
 	// Log the update operation
 	tflog.Info(ctx, fmt.Sprintf("Updating {{ .QueryName | title }} %s", state.{{ .Required | title }}.ValueString()))
 
 	// Send the update request to the API
-	response, err := infrahub_sdk.{{ .QueryName | title }}Upsert(ctx, *r.client, updateInput)
+	response, err := infrahub_sdk.{{ .QueryName | title }}Update(ctx, *r.client, updateInput)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update device in Infrahub",
@@ -199,10 +321,7 @@ func (r *{{.QueryName}}Resource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	{{- $defaultUpsertObject :=  .ObjectName }}
-	{{- range .GenqlientFields }}
-	plan.{{ .Name | title }} = types.StringValue(response.{{ $defaultUpsertObject }}Upsert.Object.{{ .PlainObject }})
-	{{- end }}
+	{{- template "response" .With "Update" "" }}
 
 	// Set the updated state with the latest data
 	diags = resp.State.Set(ctx, &plan)
@@ -252,5 +371,87 @@ func (r *{{.QueryName}}Resource) Configure(_ context.Context, req resource.Confi
 	}
 
 	r.client = &client
+	r.lineage = providerLineage(req.ProviderData)
+	{{- if .Versioned }}
+	r.conflictMode = providerConflictMode(req.ProviderData)
+	{{- end }}
+	{{- if .Dropdowns }}
+	r.choices = providerSchemaChoices(req.ProviderData)
+	{{- end }}
+	{{- if .Taggable }}
+	r.defaultTags = providerDefaultTags(req.ProviderData)
+	{{- end }}
+}
+{{- if or .Taggable .Dropdowns }}
+
+// ModifyPlan
+{{- if .Dropdowns }} checks the dropdown values against the schema choices
+{{- if .Taggable }} and{{ end }}{{ end }}
+{{- if .Taggable }} adds the provider default tags to tags_all{{ end }}.
+func (r *{{.QueryName}}Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	{{- if .Dropdowns }}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "{{ .ObjectName }}", map[string]string{
+		{{- range .Dropdowns }}
+		"{{ .HumanReadableName }}": "{{ .Dropdown }}",
+		{{- end }}
+	})...)
+	{{- end }}
+	{{- if .Taggable }}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, req, resp, r.defaultTags)...)
+	{{- end }}
 }
+{{- end }}
+{{- define "lineage" }}
+
+	// Record the provider lineage on everything written
+	{{- range .GenqlientFieldsModify }}
+	{{- if .Relationship }}
+	r.lineage.set(&{{ $.Var }}.{{ .InputObject }}.Relation__source, &{{ $.Var }}.{{ .InputObject }}.Relation__owner, &{{ $.Var }}.{{ .InputObject }}.Relation__is_protected, plan.ProtectAttributes)
+	{{- else }}
+	r.lineage.set(&{{ $.Var }}.{{ .InputObject }}.Source, &{{ $.Var }}.{{ .InputObject }}.Owner, &{{ $.Var }}.{{ .InputObject }}.Is_protected, plan.ProtectAttributes)
+	{{- end }}
+	{{- end }}
+	{{- if .Taggable }}
+	for i := range {{ $.Var }}.Tags {
+		r.lineage.set(&{{ $.Var }}.Tags[i].Relation__source, &{{ $.Var }}.Tags[i].Relation__owner, &{{ $.Var }}.Tags[i].Relation__is_protected, plan.ProtectAttributes)
+	}
+	{{- end }}
+{{- end }}
+{{- define "response" }}
+	{{- range .GenqlientFields }}
+	plan.{{ .Name | title }} = types.StringValue(response.{{ $.ObjectName }}{{ $.Op }}.Object.{{ .PlainObject }})
+	{{- end }}
+	{{- if .Taggable }}
+
+	tags := []string{}
+	for _, edge := range response.{{ .ObjectName }}{{ .Op }}.Object.Tags.Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+	plan.Tags = resourceTags(tags, plan.Tags, r.defaultTags)
+	plan.TagsAll = idSet(tags...)
+	{{- end }}
+	{{- if .Versioned }}
+	plan.UpdatedAt = updatedAt(response.{{ .ObjectName }}{{ .Op }}.Object.Updated_at)
+	{{- end }}
+	plan.AttributeOwners = lineageMap(map[string]string{
+		{{- range .MetadataFields }}
+		{{- if eq .Metadata "owner" }}
+		"{{ .HumanReadableName }}": nodeId(response.{{ $.ObjectName }}{{ $.Op }}.Object.{{ .PlainObject }}),
+		{{- end }}
+		{{- end }}
+	})
+	plan.AttributeSources = lineageMap(map[string]string{
+		{{- range .MetadataFields }}
+		{{- if eq .Metadata "source" }}
+		"{{ .HumanReadableName }}": nodeId(response.{{ $.ObjectName }}{{ $.Op }}.Object.{{ .PlainObject }}),
+		{{- end }}
+		{{- end }}
+	})
+{{- end }}
 `
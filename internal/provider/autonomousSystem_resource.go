@@ -0,0 +1,321 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &autonomousSystemResource{}
+	_ resource.ResourceWithConfigure        = &autonomousSystemResource{}
+	_ resource.ResourceWithImportState      = &autonomousSystemResource{}
+	_ resource.ResourceWithConfigValidators = &autonomousSystemResource{}
+)
+
+// NewAutonomousSystemResource is a helper function to simplify the provider implementation.
+func NewAutonomousSystemResource() resource.Resource {
+	return &autonomousSystemResource{}
+}
+
+// autonomousSystemResource is the resource implementation.
+type autonomousSystemResource struct {
+	client         *graphql.Client
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Asn            types.Int64  `tfsdk:"asn"`
+	AsnPoolId      types.String `tfsdk:"asn_pool_id"`
+	Description    types.String `tfsdk:"description"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	LocationId     types.String `tfsdk:"location_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *autonomousSystemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autonomous_system"
+}
+
+// Schema defines the schema for the resource.
+func (r *autonomousSystemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraAutonomousSystem`. The `asn` is either set explicitly or allocated from a `CoreNumberPool` when the resource is created.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"asn": schema.Int64Attribute{
+				MarkdownDescription: "2-byte or 4-byte AS number, conflicts with `asn_pool_id`",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Int64{
+					asnValidator{},
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"asn_pool_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the `CoreNumberPool` the AS number is allocated from at create time, conflicts with `asn`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": optionalComputedString(""),
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization owning the AS",
+				Required:            true,
+			},
+			"location_id": optionalComputedString("ID of the location of the AS"),
+		},
+	}
+}
+
+func (r *autonomousSystemResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("asn"),
+			path.MatchRoot("asn_pool_id"),
+		),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *autonomousSystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan autonomousSystemResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating AutonomousSystem ", plan.Name))
+
+	var fields infrahub_sdk.AutonomousSystemFields
+	if plan.AsnPoolId.ValueString() != "" {
+		response, err := infrahub_sdk.AutonomousSystemAllocate(
+			ctx,
+			*r.client,
+			plan.Name.ValueString(),
+			plan.AsnPoolId.ValueString(),
+			plan.Description.ValueString(),
+			plan.OrganizationId.ValueString(),
+			plan.LocationId.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to allocate autonomous system from pool in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		fields = response.InfraAutonomousSystemCreate.Object.AutonomousSystemFields
+	} else {
+		response, err := infrahub_sdk.AutonomousSystemCreate(
+			ctx,
+			*r.client,
+			plan.Name.ValueString(),
+			int64String(plan.Asn),
+			plan.Description.ValueString(),
+			plan.OrganizationId.ValueString(),
+			plan.LocationId.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create autonomous system in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		fields = response.InfraAutonomousSystemCreate.Object.AutonomousSystemFields
+	}
+
+	resp.Diagnostics.Append(plan.fill(fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *autonomousSystemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading AutonomousSystem...")
+	var state autonomousSystemResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.AutonomousSystem(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read autonomous system from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The AS was deleted outside of Terraform
+	if len(response.InfraAutonomousSystem.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fill(response.InfraAutonomousSystem.Edges[0].Node.AutonomousSystemFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *autonomousSystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan autonomousSystemResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state autonomousSystemResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating AutonomousSystem %s", state.Name.ValueString()))
+
+	// An AS number allocated from a pool stays the one in state
+	response, err := infrahub_sdk.AutonomousSystemUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		setDefault(int64String(plan.Asn), int64String(state.Asn)),
+		plan.Description.ValueString(),
+		plan.OrganizationId.ValueString(),
+		infrahub_sdk.RelatedNode{Id: plan.LocationId.ValueString()},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update autonomous system in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraAutonomousSystemUpdate.Object.AutonomousSystemFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *autonomousSystemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state autonomousSystemResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.AutonomousSystemDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting AutonomousSystem",
+			"Could not delete autonomous system, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an autonomous system by its node ID.
+func (r *autonomousSystemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *autonomousSystemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *autonomousSystemResource) fill(fields infrahub_sdk.AutonomousSystemFields) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	if r.Asn, err = int64Value(fields.Asn.Value); err != nil {
+		diags.AddError("Unable to parse asn returned by Infrahub", err.Error())
+	}
+
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.OrganizationId = types.StringValue(nodeId(fields.Organization.Node))
+	r.LocationId = types.StringValue(nodeId(fields.Location.Node))
+	return diags
+}
@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &bgpPeerGroupResource{}
+	_ resource.ResourceWithConfigure   = &bgpPeerGroupResource{}
+	_ resource.ResourceWithImportState = &bgpPeerGroupResource{}
+)
+
+// NewBGPPeerGroupResource is a helper function to simplify the provider implementation.
+func NewBGPPeerGroupResource() resource.Resource {
+	return &bgpPeerGroupResource{}
+}
+
+// bgpPeerGroupResource is the resource implementation.
+type bgpPeerGroupResource struct {
+	client         *graphql.Client
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	ImportPolicies types.String `tfsdk:"import_policies"`
+	ExportPolicies types.String `tfsdk:"export_policies"`
+	MaximumRoutes  types.Int64  `tfsdk:"maximum_routes"`
+	SendCommunity  types.Bool   `tfsdk:"send_community"`
+	LocalAsId      types.String `tfsdk:"local_as_id"`
+	RemoteAsId     types.String `tfsdk:"remote_as_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *bgpPeerGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bgp_peer_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *bgpPeerGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraBGPPeerGroup`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description":     optionalComputedString(""),
+			"import_policies": optionalComputedString(""),
+			"export_policies": optionalComputedString(""),
+			"maximum_routes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of routes accepted from a peer",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"send_community": schema.BoolAttribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_as_id":  optionalComputedString("ID of the local autonomous system"),
+			"remote_as_id": optionalComputedString("ID of the remote autonomous system"),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *bgpPeerGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan bgpPeerGroupResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating BGPPeerGroup ", plan.Name))
+
+	response, err := infrahub_sdk.BGPPeerGroupCreate(
+		ctx,
+		*r.client,
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		plan.ImportPolicies.ValueString(),
+		plan.ExportPolicies.ValueString(),
+		int64String(plan.MaximumRoutes),
+		plan.SendCommunity.ValueBoolPointer(),
+		plan.LocalAsId.ValueString(),
+		plan.RemoteAsId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create bgp peer group in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraBGPPeerGroupCreate.Object.BGPPeerGroupFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *bgpPeerGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading BGPPeerGroup...")
+	var state bgpPeerGroupResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.BGPPeerGroup(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read bgp peer group from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The peer group was deleted outside of Terraform
+	if len(response.InfraBGPPeerGroup.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fill(response.InfraBGPPeerGroup.Edges[0].Node.BGPPeerGroupFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *bgpPeerGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan bgpPeerGroupResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state bgpPeerGroupResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating BGPPeerGroup %s", state.Name.ValueString()))
+
+	response, err := infrahub_sdk.BGPPeerGroupUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		plan.ImportPolicies.ValueString(),
+		plan.ExportPolicies.ValueString(),
+		setDefault(int64String(plan.MaximumRoutes), int64String(state.MaximumRoutes)),
+		plan.SendCommunity.ValueBoolPointer(),
+		infrahub_sdk.RelatedNode{Id: plan.LocalAsId.ValueString()},
+		infrahub_sdk.RelatedNode{Id: plan.RemoteAsId.ValueString()},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update bgp peer group in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraBGPPeerGroupUpdate.Object.BGPPeerGroupFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *bgpPeerGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state bgpPeerGroupResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.BGPPeerGroupDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting BGPPeerGroup",
+			"Could not delete bgp peer group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a BGP peer group by its node ID.
+func (r *bgpPeerGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *bgpPeerGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *bgpPeerGroupResource) fill(fields infrahub_sdk.BGPPeerGroupFields) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	if r.MaximumRoutes, err = int64Value(fields.Maximum_routes.Value); err != nil {
+		diags.AddError("Unable to parse maximum_routes returned by Infrahub", err.Error())
+	}
+
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.ImportPolicies = types.StringValue(fields.Import_policies.Value)
+	r.ExportPolicies = types.StringValue(fields.Export_policies.Value)
+	r.SendCommunity = types.BoolValue(fields.Send_community.Value)
+	r.LocalAsId = types.StringValue(fields.Local_as.Node.Id)
+	r.RemoteAsId = types.StringValue(fields.Remote_as.Node.Id)
+	return diags
+}
@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &bgpSessionResource{}
+	_ resource.ResourceWithConfigure   = &bgpSessionResource{}
+	_ resource.ResourceWithImportState = &bgpSessionResource{}
+	_ resource.ResourceWithModifyPlan  = &bgpSessionResource{}
+)
+
+// NewBGPSessionResource is a helper function to simplify the provider implementation.
+func NewBGPSessionResource() resource.Resource {
+	return &bgpSessionResource{}
+}
+
+// bgpSessionResource is the resource implementation.
+type bgpSessionResource struct {
+	client         *graphql.Client
+	choices        *schemaChoices
+	Id             types.String `tfsdk:"id"`
+	SessionType    types.String `tfsdk:"session_type"`
+	Description    types.String `tfsdk:"description"`
+	ImportPolicies types.String `tfsdk:"import_policies"`
+	ExportPolicies types.String `tfsdk:"export_policies"`
+	Status         types.String `tfsdk:"status"`
+	Role           types.String `tfsdk:"role"`
+	LocalAsId      types.String `tfsdk:"local_as_id"`
+	RemoteAsId     types.String `tfsdk:"remote_as_id"`
+	LocalIpId      types.String `tfsdk:"local_ip_id"`
+	RemoteIpId     types.String `tfsdk:"remote_ip_id"`
+	DeviceId       types.String `tfsdk:"device_id"`
+	PeerGroupId    types.String `tfsdk:"peer_group_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *bgpSessionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bgp_session"
+}
+
+// Schema defines the schema for the resource.
+func (r *bgpSessionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraBGPSession`. Sessions whose local and remote IP are the same address are rejected at plan time.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"session_type": schema.StringAttribute{
+				MarkdownDescription: "Session type, e.g. `EXTERNAL` or `INTERNAL`",
+				Required:            true,
+			},
+			"description":     optionalComputedString(""),
+			"import_policies": optionalComputedString(""),
+			"export_policies": optionalComputedString(""),
+			"status": schema.StringAttribute{
+				Required: true,
+			},
+			"role": schema.StringAttribute{
+				Required: true,
+			},
+			"local_as_id":   optionalComputedString("ID of the local autonomous system"),
+			"remote_as_id":  optionalComputedString("ID of the remote autonomous system"),
+			"local_ip_id":   optionalComputedString("ID of the local IP address"),
+			"remote_ip_id":  optionalComputedString("ID of the remote IP address"),
+			"device_id":     optionalComputedString("ID of the device the session is configured on"),
+			"peer_group_id": optionalComputedString("ID of the BGP peer group"),
+		},
+	}
+}
+
+// ModifyPlan checks the dropdown values against the schema choices and
+// rejects sessions whose local and remote IP resolve to the same address.
+func (r *bgpSessionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed or the provider isn't configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "InfraBGPSession", map[string]string{
+		"status": "status",
+		"role":   "role",
+	})...)
+
+	var plan bgpSessionResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	localIpId, remoteIpId := plan.LocalIpId.ValueString(), plan.RemoteIpId.ValueString()
+	if localIpId == "" || remoteIpId == "" {
+		return
+	}
+
+	if localIpId == remoteIpId {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("remote_ip_id"),
+			"Local and remote IP are the same",
+			fmt.Sprintf("The session uses IP address %s on both ends.", localIpId),
+		)
+		return
+	}
+
+	local, err := r.address(ctx, localIpId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("local_ip_id"), "Unable to read ip address from Infrahub", err.Error())
+		return
+	}
+	remote, err := r.address(ctx, remoteIpId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("remote_ip_id"), "Unable to read ip address from Infrahub", err.Error())
+		return
+	}
+
+	if local.Addr() == remote.Addr() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("remote_ip_id"),
+			"Local and remote IP are the same",
+			fmt.Sprintf("IP addresses %s and %s are both %s.", localIpId, remoteIpId, local.Addr()),
+		)
+	}
+}
+
+// address returns the address of the InfraIPAddress with the given ID.
+func (r *bgpSessionResource) address(ctx context.Context, id string) (netip.Prefix, error) {
+	response, err := infrahub_sdk.IPAddress(ctx, *r.client, id)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if len(response.InfraIPAddress.Edges) != 1 {
+		return netip.Prefix{}, fmt.Errorf("expected exactly 1 ip address with ID %s, got %d", id, len(response.InfraIPAddress.Edges))
+	}
+	return netip.ParsePrefix(response.InfraIPAddress.Edges[0].Node.Address.Value)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *bgpSessionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan bgpSessionResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Creating BGPSession")
+
+	response, err := infrahub_sdk.BGPSessionCreate(
+		ctx,
+		*r.client,
+		plan.SessionType.ValueString(),
+		plan.Description.ValueString(),
+		plan.ImportPolicies.ValueString(),
+		plan.ExportPolicies.ValueString(),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.LocalAsId.ValueString(),
+		plan.RemoteAsId.ValueString(),
+		plan.LocalIpId.ValueString(),
+		plan.RemoteIpId.ValueString(),
+		plan.DeviceId.ValueString(),
+		plan.PeerGroupId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create bgp session in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraBGPSessionCreate.Object.BGPSessionFields)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *bgpSessionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading BGPSession...")
+	var state bgpSessionResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.BGPSession(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read bgp session from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The session was deleted outside of Terraform
+	if len(response.InfraBGPSession.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.fill(response.InfraBGPSession.Edges[0].Node.BGPSessionFields)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *bgpSessionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan bgpSessionResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state bgpSessionResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating BGPSession %s", state.Id.ValueString()))
+
+	// Unset relationships keep their state, an empty ID clears them
+	response, err := infrahub_sdk.BGPSessionUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.SessionType.ValueString(),
+		plan.Description.ValueString(),
+		plan.ImportPolicies.ValueString(),
+		plan.ExportPolicies.ValueString(),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		infrahub_sdk.RelatedNode{Id: plan.LocalAsId.ValueString()},
+		infrahub_sdk.RelatedNode{Id: plan.RemoteAsId.ValueString()},
+		infrahub_sdk.RelatedNode{Id: plan.LocalIpId.ValueString()},
+		infrahub_sdk.RelatedNode{Id: plan.RemoteIpId.ValueString()},
+		infrahub_sdk.RelatedNode{Id: plan.DeviceId.ValueString()},
+		infrahub_sdk.RelatedNode{Id: plan.PeerGroupId.ValueString()},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update bgp session in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraBGPSessionUpdate.Object.BGPSessionFields)
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *bgpSessionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state bgpSessionResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.BGPSessionDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting BGPSession",
+			"Could not delete bgp session, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a BGP session by its node ID.
+func (r *bgpSessionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *bgpSessionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *bgpSessionResource) fill(fields infrahub_sdk.BGPSessionFields) {
+	r.Id = types.StringValue(fields.Id)
+	r.SessionType = types.StringValue(fields.Type.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.ImportPolicies = types.StringValue(fields.Import_policies.Value)
+	r.ExportPolicies = types.StringValue(fields.Export_policies.Value)
+	r.Status = types.StringValue(fields.Status.Value)
+	r.Role = types.StringValue(fields.Role.Value)
+	r.LocalAsId = types.StringValue(fields.Local_as.Node.Id)
+	r.RemoteAsId = types.StringValue(fields.Remote_as.Node.Id)
+	r.LocalIpId = types.StringValue(fields.Local_ip.Node.Id)
+	r.RemoteIpId = types.StringValue(fields.Remote_ip.Node.Id)
+	r.DeviceId = types.StringValue(fields.Device.Node.Id)
+	r.PeerGroupId = types.StringValue(fields.Peer_group.Node.Id)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// schemaChoices holds the allowed values of the dropdown and enum attributes
+// of every kind. The GraphQL schema only has the attribute types, so there is
+// no static list of choices: the values are read from the schema API when the
+// provider is configured, and the resources changing the schema update them
+// while they are planned. A nil schemaChoices checks nothing.
+type schemaChoices struct {
+	mu sync.Mutex
+	// values is keyed by kind and attribute name.
+	values map[string]map[string][]string
+	// stale is set when an infrahub_schema resource changes the schema during
+	// the run, the values may then miss choices it adds.
+	stale bool
+}
+
+// loadSchemaChoices reads the dropdown choices and enum values of every kind
+// from the schema API.
+func loadSchemaChoices(ctx context.Context, api schemaAPI) (*schemaChoices, error) {
+	nodes, err := api.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]map[string][]string{}
+	for _, node := range nodes {
+		for _, attribute := range node.Attributes {
+			allowed := []string{}
+			for _, choice := range attribute.Choices {
+				allowed = append(allowed, choice.Name)
+			}
+			for _, value := range attribute.Enum {
+				allowed = append(allowed, fmt.Sprint(value))
+			}
+			if len(allowed) == 0 {
+				continue
+			}
+			if values[node.Kind] == nil {
+				values[node.Kind] = map[string][]string{}
+			}
+			values[node.Kind][attribute.Name] = allowed
+		}
+	}
+	return &schemaChoices{values: values}, nil
+}
+
+// providerSchemaChoices returns the choices fetched by the provider from the
+// provider data handed to Configure, nil unless `fetch_schema_choices` is set.
+func providerSchemaChoices(providerData any) *schemaChoices {
+	if client, ok := providerData.(*infrahubClient); ok {
+		return client.choices
+	}
+	return nil
+}
+
+// add allows a value of an attribute of a kind, e.g. a choice planned by an
+// infrahub_schema_dropdown_choice resource. Attributes without known choices
+// accept any value already and are left unchanged.
+func (c *schemaChoices) add(kind string, attribute string, value string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := c.values[kind][attribute]
+	if len(values) > 0 && !slices.Contains(values, value) {
+		c.values[kind][attribute] = append(values, value)
+	}
+}
+
+// invalidate stops reporting values missing from the choices, because the
+// schema is changed during the run.
+func (c *schemaChoices) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stale = true
+}
+
+// allowed returns an error when value is not allowed by the attribute of the
+// kind. Attributes without known choices accept any value.
+func (c *schemaChoices) allowed(kind string, attribute string, value string) error {
+	if c == nil || value == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := c.values[kind][attribute]
+	if c.stale || len(values) == 0 || slices.Contains(values, value) {
+		return nil
+	}
+	return fmt.Errorf("%q is not a choice of %s %s, expected one of: %s", value, kind, attribute, strings.Join(values, ", "))
+}
+
+// check reports planned values that are not allowed by the schema of the
+// kind. attributes maps the Terraform attribute names to the attribute names
+// of the kind, attributes without known choices are not checked.
+func (c *schemaChoices) check(ctx context.Context, plan tfsdk.Plan, kind string, attributes map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if c == nil {
+		return diags
+	}
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		var value types.String
+		diags.Append(plan.GetAttribute(ctx, path.Root(name), &value)...)
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if err := c.allowed(kind, attributes[name], value.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root(name), "Invalid choice", err.Error())
+		}
+	}
+	return diags
+}
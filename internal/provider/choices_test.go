@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testSchemaChoices() *schemaChoices {
+	return &schemaChoices{values: map[string]map[string][]string{
+		"InfraDevice": {
+			"role":   {"spine", "leaf"},
+			"status": {"active", "provisioning"},
+		},
+	}}
+}
+
+func TestSchemaChoicesAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		choices   *schemaChoices
+		kind      string
+		attribute string
+		value     string
+		wantError bool
+	}{
+		{name: "choice", choices: testSchemaChoices(), kind: "InfraDevice", attribute: "role", value: "leaf"},
+		{name: "not a choice", choices: testSchemaChoices(), kind: "InfraDevice", attribute: "role", value: "laef", wantError: true},
+		{name: "empty", choices: testSchemaChoices(), kind: "InfraDevice", attribute: "role", value: ""},
+		{name: "attribute without choices", choices: testSchemaChoices(), kind: "InfraDevice", attribute: "name", value: "leaf1"},
+		{name: "unknown kind", choices: testSchemaChoices(), kind: "InfraCircuit", attribute: "status", value: "typo"},
+		{name: "not fetched", kind: "InfraDevice", attribute: "role", value: "laef"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.choices.allowed(test.kind, test.attribute, test.value)
+			if (err != nil) != test.wantError {
+				t.Errorf("allowed() error = %v, want error %t", err, test.wantError)
+			}
+		})
+	}
+}
+
+func TestSchemaChoicesChangedDuringRun(t *testing.T) {
+	choices := testSchemaChoices()
+	choices.add("InfraDevice", "role", "border_leaf")
+	if err := choices.allowed("InfraDevice", "role", "border_leaf"); err != nil {
+		t.Errorf("planned choice: %v", err)
+	}
+	if err := choices.allowed("InfraDevice", "role", "spine"); err != nil {
+		t.Errorf("existing choice after add: %v", err)
+	}
+
+	// Attributes without choices stay unchecked instead of allowing only the
+	// planned value
+	choices.add("InfraDevice", "name", "leaf1")
+	if err := choices.allowed("InfraDevice", "name", "leaf2"); err != nil {
+		t.Errorf("attribute without choices after add: %v", err)
+	}
+
+	if err := choices.allowed("InfraDevice", "status", "typo"); err == nil {
+		t.Error("typo before invalidate: expected an error")
+	}
+	choices.invalidate()
+	if err := choices.allowed("InfraDevice", "status", "typo"); err != nil {
+		t.Errorf("typo after invalidate: %v", err)
+	}
+
+	// A provider without fetch_schema_choices has no choices
+	var disabled *schemaChoices
+	disabled.add("InfraDevice", "role", "border_leaf")
+	disabled.invalidate()
+}
+
+func TestSchemaChoicesCheck(t *testing.T) {
+	planSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"role_value":   schema.StringAttribute{Optional: true},
+			"status_value": schema.StringAttribute{Optional: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"role_value":   tftypes.String,
+		"status_value": tftypes.String,
+	}}
+	attributes := map[string]string{"role_value": "role", "status_value": "status"}
+
+	tests := []struct {
+		name       string
+		role       tftypes.Value
+		status     tftypes.Value
+		wantErrors int
+	}{
+		{
+			name:   "choices",
+			role:   tftypes.NewValue(tftypes.String, "spine"),
+			status: tftypes.NewValue(tftypes.String, "active"),
+		},
+		{
+			name:       "one typo",
+			role:       tftypes.NewValue(tftypes.String, "spnie"),
+			status:     tftypes.NewValue(tftypes.String, "active"),
+			wantErrors: 1,
+		},
+		{
+			name:       "two typos",
+			role:       tftypes.NewValue(tftypes.String, "spnie"),
+			status:     tftypes.NewValue(tftypes.String, "actve"),
+			wantErrors: 2,
+		},
+		{
+			name:   "null and unknown",
+			role:   tftypes.NewValue(tftypes.String, nil),
+			status: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := tfsdk.Plan{
+				Schema: planSchema,
+				Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+					"role_value":   test.role,
+					"status_value": test.status,
+				}),
+			}
+			diags := testSchemaChoices().check(context.Background(), plan, "InfraDevice", attributes)
+			if diags.ErrorsCount() != test.wantErrors {
+				t.Errorf("check() = %v, want %d errors", diags, test.wantErrors)
+			}
+
+			var disabled *schemaChoices
+			if diags := disabled.check(context.Background(), plan, "InfraDevice", attributes); diags.HasError() {
+				t.Errorf("check() without choices = %v, want no errors", diags)
+			}
+		})
+	}
+}
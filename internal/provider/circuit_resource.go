@@ -0,0 +1,455 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &circuitResource{}
+	_ resource.ResourceWithConfigure   = &circuitResource{}
+	_ resource.ResourceWithImportState = &circuitResource{}
+	_ resource.ResourceWithModifyPlan  = &circuitResource{}
+)
+
+// NewCircuitResource is a helper function to simplify the provider implementation.
+func NewCircuitResource() resource.Resource {
+	return &circuitResource{}
+}
+
+// circuitResource is the resource implementation.
+type circuitResource struct {
+	client        *graphql.Client
+	choices       *schemaChoices
+	Id            types.String           `tfsdk:"id"`
+	CircuitId     types.String           `tfsdk:"circuit_id"`
+	Description   types.String           `tfsdk:"description"`
+	VendorId      types.String           `tfsdk:"vendor_id"`
+	Status        types.String           `tfsdk:"status"`
+	Role          types.String           `tfsdk:"role"`
+	ProviderId    types.String           `tfsdk:"provider_id"`
+	CircuitTypeId types.String           `tfsdk:"circuit_type_id"`
+	Endpoints     []circuitEndpointModel `tfsdk:"endpoints"`
+}
+
+// circuitEndpointModel is one side of a circuit, stored as an InfraCircuitEndpoint.
+type circuitEndpointModel struct {
+	Id                  types.String `tfsdk:"id"`
+	Description         types.String `tfsdk:"description"`
+	LocationId          types.String `tfsdk:"location_id"`
+	ConnectedEndpointId types.String `tfsdk:"connected_endpoint_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *circuitResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_circuit"
+}
+
+// Schema defines the schema for the resource.
+func (r *circuitResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraCircuit` together with its `InfraCircuitEndpoint` children.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"circuit_id": schema.StringAttribute{
+				MarkdownDescription: "Circuit identifier",
+				Required:            true,
+			},
+			"description": optionalComputedString(""),
+			"vendor_id":   optionalComputedString("Identifier of the circuit at the provider"),
+			"status": schema.StringAttribute{
+				Required: true,
+			},
+			"role": schema.StringAttribute{
+				Required: true,
+			},
+			"provider_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the provider organization",
+				Required:            true,
+			},
+			"circuit_type_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the circuit type",
+				Required:            true,
+			},
+			"endpoints": schema.ListNestedAttribute{
+				MarkdownDescription: "Endpoints of the circuit, the A side first and the Z side second. Endpoints are created and deleted with the circuit.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 2),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"description": optionalComputedString(""),
+						"location_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the location the endpoint terminates in",
+							Required:            true,
+						},
+						"connected_endpoint_id": optionalComputedString("ID of the interface the endpoint is connected to"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *circuitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan circuitResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating Circuit ", plan.CircuitId))
+
+	response, err := infrahub_sdk.CircuitCreate(
+		ctx,
+		*r.client,
+		plan.CircuitId.ValueString(),
+		plan.Description.ValueString(),
+		plan.VendorId.ValueString(),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.ProviderId.ValueString(),
+		plan.CircuitTypeId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create circuit in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraCircuitCreate.Object.CircuitFields)
+
+	// Endpoints that were created before a failure are kept in state so
+	// that the tainted circuit is cleaned up completely on the next apply.
+	endpoints := plan.Endpoints
+	plan.Endpoints = nil
+	for _, endpoint := range endpoints {
+		created, err := infrahub_sdk.CircuitEndpointCreate(
+			ctx,
+			*r.client,
+			plan.Id.ValueString(),
+			endpoint.Description.ValueString(),
+			endpoint.LocationId.ValueString(),
+			endpoint.ConnectedEndpointId.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create circuit endpoint in Infrahub",
+				err.Error(),
+			)
+			break
+		}
+		endpoint.fill(created.InfraCircuitEndpointCreate.Object.CircuitEndpointFields)
+		plan.Endpoints = append(plan.Endpoints, endpoint)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *circuitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading Circuit...")
+	var state circuitResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.Circuit(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read circuit from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The circuit was deleted outside of Terraform
+	if len(response.InfraCircuit.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	node := response.InfraCircuit.Edges[0].Node
+	state.fill(node.CircuitFields)
+
+	// Keep the A/Z order of the state, endpoints added outside of
+	// Terraform are appended so that they show up as drift.
+	current := map[string]infrahub_sdk.CircuitEndpointFields{}
+	var order []string
+	for _, edge := range node.Endpoints.Edges {
+		current[edge.Node.Id] = edge.Node.CircuitEndpointFields
+		order = append(order, edge.Node.Id)
+	}
+	var endpoints []circuitEndpointModel
+	for _, endpoint := range state.Endpoints {
+		fields, ok := current[endpoint.Id.ValueString()]
+		if !ok {
+			continue
+		}
+		endpoint.fill(fields)
+		endpoints = append(endpoints, endpoint)
+		delete(current, endpoint.Id.ValueString())
+	}
+	for _, id := range order {
+		if fields, ok := current[id]; ok {
+			var endpoint circuitEndpointModel
+			endpoint.fill(fields)
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	state.Endpoints = endpoints
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *circuitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan circuitResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state circuitResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating Circuit %s", state.CircuitId.ValueString()))
+
+	response, err := infrahub_sdk.CircuitUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.CircuitId.ValueString(),
+		plan.Description.ValueString(),
+		plan.VendorId.ValueString(),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.ProviderId.ValueString(),
+		plan.CircuitTypeId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update circuit in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraCircuitUpdate.Object.CircuitFields)
+
+	// Endpoints are matched by position: existing sides are updated in
+	// place, new sides are created and sides no longer planned are deleted.
+	// After a failure the state keeps the endpoints applied so far and the
+	// sides of the prior state not reached yet, so that none is orphaned.
+	endpoints := plan.Endpoints
+	plan.Endpoints = nil
+	for i, endpoint := range endpoints {
+		if i < len(state.Endpoints) {
+			updated, err := infrahub_sdk.CircuitEndpointUpdate(
+				ctx,
+				*r.client,
+				state.Endpoints[i].Id.ValueString(),
+				plan.Id.ValueString(),
+				endpoint.Description.ValueString(),
+				endpoint.LocationId.ValueString(),
+				infrahub_sdk.RelatedNode{Id: endpoint.ConnectedEndpointId.ValueString()},
+			)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to update circuit endpoint in Infrahub",
+					err.Error(),
+				)
+				break
+			}
+			endpoint.fill(updated.InfraCircuitEndpointUpdate.Object.CircuitEndpointFields)
+			plan.Endpoints = append(plan.Endpoints, endpoint)
+			continue
+		}
+
+		created, err := infrahub_sdk.CircuitEndpointCreate(
+			ctx,
+			*r.client,
+			plan.Id.ValueString(),
+			endpoint.Description.ValueString(),
+			endpoint.LocationId.ValueString(),
+			endpoint.ConnectedEndpointId.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create circuit endpoint in Infrahub",
+				err.Error(),
+			)
+			break
+		}
+		endpoint.fill(created.InfraCircuitEndpointCreate.Object.CircuitEndpointFields)
+		plan.Endpoints = append(plan.Endpoints, endpoint)
+	}
+
+	// Index of the first side of the prior state that is neither updated nor deleted
+	remaining := len(plan.Endpoints)
+	if !resp.Diagnostics.HasError() {
+		for ; remaining < len(state.Endpoints); remaining++ {
+			_, err := infrahub_sdk.CircuitEndpointDelete(ctx, *r.client, state.Endpoints[remaining].Id.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to delete circuit endpoint in Infrahub",
+					err.Error(),
+				)
+				break
+			}
+		}
+	}
+	if remaining < len(state.Endpoints) {
+		plan.Endpoints = append(plan.Endpoints, state.Endpoints[remaining:]...)
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *circuitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state circuitResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, endpoint := range state.Endpoints {
+		_, err := infrahub_sdk.CircuitEndpointDelete(ctx, *r.client, endpoint.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting CircuitEndpoint",
+				"Could not delete circuit endpoint, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	_, err := infrahub_sdk.CircuitDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Circuit",
+			"Could not delete circuit, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a circuit and its endpoints by the circuit node ID.
+func (r *circuitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan checks the dropdown values against the schema choices.
+func (r *circuitResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "InfraCircuit", map[string]string{
+		"status": "status",
+		"role":   "role",
+	})...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *circuitResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *circuitResource) fill(fields infrahub_sdk.CircuitFields) {
+	r.Id = types.StringValue(fields.Id)
+	r.CircuitId = types.StringValue(fields.Circuit_id.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.VendorId = types.StringValue(fields.Vendor_id.Value)
+	r.Status = types.StringValue(fields.Status.Value)
+	r.Role = types.StringValue(fields.Role.Value)
+	r.ProviderId = types.StringValue(fields.Provider.Node.Id)
+	r.CircuitTypeId = types.StringValue(fields.Circuit_type.Node.Id)
+}
+
+// fill copies the fields returned by Infrahub into the endpoint model.
+func (e *circuitEndpointModel) fill(fields infrahub_sdk.CircuitEndpointFields) {
+	e.Id = types.StringValue(fields.Id)
+	e.Description = types.StringValue(fields.Description.Value)
+	e.LocationId = types.StringValue(nodeId(fields.Location.Node))
+	e.ConnectedEndpointId = types.StringValue(nodeId(fields.Connected_endpoint.Node))
+}
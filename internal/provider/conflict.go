@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of the provider `conflict_mode`.
+const (
+	conflictModeError = "error"
+	conflictModeWarn  = "warn"
+)
+
+// providerConflictMode returns the provider `conflict_mode` from the provider
+// data handed to Configure.
+func providerConflictMode(providerData any) string {
+	if client, ok := providerData.(*infrahubClient); ok {
+		return client.conflictMode
+	}
+	return conflictModeError
+}
+
+// updatedAtAttribute returns the schema of the `updated_at` attribute.
+func updatedAtAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Time the node was last changed in Infrahub, checked before every update to detect changes made outside of Terraform",
+		Computed:            true,
+	}
+}
+
+// updatedAt converts the `_updated_at` of a node into its state value.
+func updatedAt(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339Nano))
+}
+
+// checkConflict reports a node that was modified in Infrahub after it was
+// last read, as an error or a warning depending on the conflict mode.
+// changed lists the attributes whose value differs from the state.
+func checkConflict(mode string, kind string, id string, recorded types.String, current time.Time, changed []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if recorded.IsNull() || recorded.IsUnknown() || recorded.Equal(updatedAt(current)) {
+		return diags
+	}
+
+	detail := fmt.Sprintf("%s %s was modified in Infrahub at %s, after it was last read at %s.",
+		kind, id, updatedAt(current).ValueString(), recorded.ValueString())
+	if len(changed) > 0 {
+		detail += " Changed attributes: " + strings.Join(changed, ", ") + "."
+	}
+
+	if mode == conflictModeWarn {
+		diags.AddWarning("Overwriting changes made outside of Terraform", detail)
+		return diags
+	}
+	diags.AddError("Conflicting changes in Infrahub",
+		detail+" Run terraform plan again to review the changes, or set the provider conflict_mode to \"warn\" to overwrite them.")
+	return diags
+}
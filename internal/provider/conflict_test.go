@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckConflict(t *testing.T) {
+	read := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	modified := read.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		mode        string
+		recorded    types.String
+		current     time.Time
+		changed     []string
+		wantError   bool
+		wantWarning bool
+		wantDetail  string
+	}{
+		{
+			name:     "unchanged",
+			mode:     conflictModeError,
+			recorded: updatedAt(read),
+			current:  read,
+		},
+		{
+			name:     "unchanged in another time zone",
+			mode:     conflictModeError,
+			recorded: updatedAt(read),
+			current:  read.In(time.FixedZone("CEST", 2*60*60)),
+		},
+		{
+			name:     "not recorded yet",
+			mode:     conflictModeError,
+			recorded: types.StringNull(),
+			current:  modified,
+		},
+		{
+			name:     "unknown",
+			mode:     conflictModeError,
+			recorded: types.StringUnknown(),
+			current:  modified,
+		},
+		{
+			name:       "modified",
+			mode:       conflictModeError,
+			recorded:   updatedAt(read),
+			current:    modified,
+			changed:    []string{"description", "role"},
+			wantError:  true,
+			wantDetail: "Changed attributes: description, role.",
+		},
+		{
+			name:        "modified with warn",
+			mode:        conflictModeWarn,
+			recorded:    updatedAt(read),
+			current:     modified,
+			wantWarning: true,
+			wantDetail:  "InfraDevice device-1 was modified in Infrahub at 2024-05-01T12:01:00Z",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diags := checkConflict(test.mode, "InfraDevice", "device-1", test.recorded, test.current, test.changed)
+			if diags.HasError() != test.wantError {
+				t.Errorf("error = %t, want %t", diags.HasError(), test.wantError)
+			}
+			if got := diags.WarningsCount() > 0; got != test.wantWarning {
+				t.Errorf("warning = %t, want %t", got, test.wantWarning)
+			}
+			if test.wantDetail != "" && (len(diags) != 1 || !strings.Contains(diags[0].Detail(), test.wantDetail)) {
+				t.Errorf("diagnostics = %v, want detail containing %q", diags, test.wantDetail)
+			}
+		})
+	}
+}
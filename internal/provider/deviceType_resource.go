@@ -0,0 +1,342 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &deviceTypeResource{}
+	_ resource.ResourceWithConfigure   = &deviceTypeResource{}
+	_ resource.ResourceWithImportState = &deviceTypeResource{}
+	_ resource.ResourceWithModifyPlan  = &deviceTypeResource{}
+)
+
+// NewDeviceTypeResource is a helper function to simplify the provider implementation.
+func NewDeviceTypeResource() resource.Resource {
+	return &deviceTypeResource{}
+}
+
+// deviceTypeResource is the resource implementation.
+type deviceTypeResource struct {
+	client         *graphql.Client
+	defaultTags    []string
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	PartNumber     types.String `tfsdk:"part_number"`
+	Height         types.Int64  `tfsdk:"height"`
+	Weight         types.Int64  `tfsdk:"weight"`
+	FullDepth      types.Bool   `tfsdk:"full_depth"`
+	ManufacturerId types.String `tfsdk:"manufacturer_id"`
+	PlatformId     types.String `tfsdk:"platform_id"`
+	Tags           types.Set    `tfsdk:"tags"`
+	TagsAll        types.Set    `tfsdk:"tags_all"`
+}
+
+// Metadata returns the resource type name.
+func (r *deviceTypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_type"
+}
+
+// Schema defines the schema for the resource.
+func (r *deviceTypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraDeviceType`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": optionalComputedString(""),
+			"part_number": optionalComputedString("Part number of the manufacturer"),
+			"height": schema.Int64Attribute{
+				MarkdownDescription: "Height in rack units",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"weight": schema.Int64Attribute{
+				MarkdownDescription: "Weight in kilograms",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"full_depth": schema.BoolAttribute{
+				MarkdownDescription: "Whether the device takes the full depth of a rack",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"manufacturer_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the manufacturer",
+				Required:            true,
+			},
+			"platform_id": optionalComputedString("ID of the platform running on the device type"),
+			"tags":        tagsAttribute(),
+			"tags_all":    tagsAllAttribute(),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *deviceTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan deviceTypeResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating DeviceType ", plan.Name))
+
+	response, err := infrahub_sdk.DeviceTypeCreate(
+		ctx,
+		*r.client,
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		plan.PartNumber.ValueString(),
+		int64String(plan.Height),
+		int64String(plan.Weight),
+		plan.FullDepth.ValueBoolPointer(),
+		plan.ManufacturerId.ValueString(),
+		plan.PlatformId.ValueString(),
+		relatedNodes(mergeTags(plan.Tags, r.defaultTags)),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create device type in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraDeviceTypeCreate.Object.DeviceTypeFields, r.defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *deviceTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading DeviceType...")
+	var state deviceTypeResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.DeviceType(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read device type from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The device type was deleted outside of Terraform
+	if len(response.InfraDeviceType.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fill(response.InfraDeviceType.Edges[0].Node.DeviceTypeFields, r.defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *deviceTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan deviceTypeResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state deviceTypeResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating DeviceType %s", state.Name.ValueString()))
+
+	response, err := infrahub_sdk.DeviceTypeUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		plan.PartNumber.ValueString(),
+		setDefault(int64String(plan.Height), int64String(state.Height)),
+		setDefault(int64String(plan.Weight), int64String(state.Weight)),
+		plan.FullDepth.ValueBoolPointer(),
+		plan.ManufacturerId.ValueString(),
+		infrahub_sdk.RelatedNode{Id: plan.PlatformId.ValueString()},
+		relatedNodes(mergeTags(plan.Tags, r.defaultTags)),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update device type in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraDeviceTypeUpdate.Object.DeviceTypeFields, r.defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *deviceTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state deviceTypeResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.DeviceTypeDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting DeviceType",
+			"Could not delete device type, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a device type by its node ID.
+func (r *deviceTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *deviceTypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.defaultTags = providerDefaultTags(req.ProviderData)
+}
+
+// ModifyPlan adds the provider default tags to tags_all.
+func (r *deviceTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, req, resp, r.defaultTags)...)
+}
+
+// fill copies the fields returned by Infrahub into the resource model,
+// leaving the default tags out of tags.
+func (r *deviceTypeResource) fill(fields infrahub_sdk.DeviceTypeFields, defaultTags []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	if r.Height, err = int64Value(fields.Height.Value); err != nil {
+		diags.AddError("Unable to parse height returned by Infrahub", err.Error())
+	}
+	if r.Weight, err = int64Value(fields.Weight.Value); err != nil {
+		diags.AddError("Unable to parse weight returned by Infrahub", err.Error())
+	}
+
+	tags := []string{}
+	for _, edge := range fields.Tags.Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.PartNumber = types.StringValue(fields.Part_number.Value)
+	r.FullDepth = types.BoolValue(fields.Full_depth.Value)
+	r.ManufacturerId = types.StringValue(fields.Manufacturer.Node.Id)
+	r.PlatformId = types.StringValue(fields.Platform.Node.Id)
+	r.Tags = resourceTags(tags, r.Tags, defaultTags)
+	r.TagsAll = idSet(tags...)
+	return diags
+}
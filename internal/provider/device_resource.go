@@ -17,8 +17,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &deviceResource{}
-	_ resource.ResourceWithConfigure = &deviceResource{}
+	_ resource.Resource               = &deviceResource{}
+	_ resource.ResourceWithConfigure  = &deviceResource{}
+	_ resource.ResourceWithModifyPlan = &deviceResource{}
 )
 
 // NewDeviceResource is a helper function to simplify the provider implementation.
@@ -29,6 +30,10 @@ func NewDeviceResource() resource.Resource {
 // deviceResource is the resource implementation.
 type deviceResource struct {
 	client                              *graphql.Client
+	lineage                             lineage
+	defaultTags                         []string
+	conflictMode                        string
+	choices                             *schemaChoices
 	Edges_node_id                       types.String `tfsdk:"id"`
 	Edges_node_name_value               types.String `tfsdk:"name_value"`
 	Edges_node_role_value               types.String `tfsdk:"role_value"`
@@ -44,6 +49,13 @@ type deviceResource struct {
 	Edges_node_status_value             types.String `tfsdk:"status_value"`
 	Edges_node_topology_node_id         types.String `tfsdk:"topology_node_id"`
 	Edges_node_topology_node_name_value types.String `tfsdk:"topology_node_name_value"`
+	Tags                                types.Set    `tfsdk:"tags"`
+	TagsAll                             types.Set    `tfsdk:"tags_all"`
+	UpdatedAt                           types.String `tfsdk:"updated_at"`
+	CreateOrAdopt                       types.Bool   `tfsdk:"create_or_adopt"`
+	ProtectAttributes                   types.Bool   `tfsdk:"protect_attributes"`
+	AttributeOwners                     types.Map    `tfsdk:"attribute_owners"`
+	AttributeSources                    types.Map    `tfsdk:"attribute_sources"`
 }
 
 // Metadata returns the resource type name.
@@ -109,8 +121,18 @@ func (r *deviceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Computed: true,
 				Optional: true,
 			},
+			"tags":       tagsAttribute(),
+			"tags_all":   tagsAllAttribute(),
+			"updated_at": updatedAtAttribute(),
+			"create_or_adopt": schema.BoolAttribute{
+				MarkdownDescription: "Take over an existing device with the same human friendly ID instead of failing on create",
+				Optional:            true,
+			},
 		},
 	}
+	for name, attribute := range lineageAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -138,32 +160,143 @@ func (r *deviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	defaultDevice.Primary_address.Id = plan.Edges_node_primary_address_node_id.ValueString()
 	defaultDevice.Status.Value = plan.Edges_node_status_value.ValueString()
 	defaultDevice.Topology.Id = plan.Edges_node_topology_node_id.ValueString()
+	defaultDevice.Tags = relatedNodeInputs(mergeTags(plan.Tags, r.defaultTags))
+
+	// Record the provider lineage on everything written
+	r.lineage.set(&defaultDevice.Name.Source, &defaultDevice.Name.Owner, &defaultDevice.Name.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Role.Source, &defaultDevice.Role.Owner, &defaultDevice.Role.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Asn.Relation__source, &defaultDevice.Asn.Relation__owner, &defaultDevice.Asn.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Description.Source, &defaultDevice.Description.Owner, &defaultDevice.Description.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Device_type.Relation__source, &defaultDevice.Device_type.Relation__owner, &defaultDevice.Device_type.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Location.Relation__source, &defaultDevice.Location.Relation__owner, &defaultDevice.Location.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Platform.Relation__source, &defaultDevice.Platform.Relation__owner, &defaultDevice.Platform.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Primary_address.Relation__source, &defaultDevice.Primary_address.Relation__owner, &defaultDevice.Primary_address.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Status.Source, &defaultDevice.Status.Owner, &defaultDevice.Status.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&defaultDevice.Topology.Relation__source, &defaultDevice.Topology.Relation__owner, &defaultDevice.Topology.Relation__is_protected, plan.ProtectAttributes)
+	for i := range defaultDevice.Tags {
+		r.lineage.set(&defaultDevice.Tags[i].Relation__source, &defaultDevice.Tags[i].Relation__owner, &defaultDevice.Tags[i].Relation__is_protected, plan.ProtectAttributes)
+	}
 
 	tflog.Info(ctx, fmt.Sprint("Creating Device ", plan.Edges_node_name_value))
 
-	response, err := infrahub_sdk.DeviceCreate(ctx, *r.client, defaultDevice)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create device in Infrahub",
-			err.Error(),
-		)
-		return
+	if plan.CreateOrAdopt.ValueBool() {
+		// Upsert takes over an existing device with the same human friendly ID
+		var adoptInput infrahub_sdk.InfraDeviceUpsertInput
+		adoptInput.Name.Value = plan.Edges_node_name_value.ValueString()
+		adoptInput.Role.Value = plan.Edges_node_role_value.ValueString()
+		adoptInput.Asn.Id = plan.Edges_node_asn_node_id.ValueString()
+		adoptInput.Description.Value = plan.Edges_node_description_value.ValueString()
+		adoptInput.Device_type.Id = plan.Edges_node_device_type_node_id.ValueString()
+		adoptInput.Location.Id = plan.Edges_node_location_node_id.ValueString()
+		adoptInput.Platform.Id = plan.Edges_node_platform_node_id.ValueString()
+		adoptInput.Primary_address.Id = plan.Edges_node_primary_address_node_id.ValueString()
+		adoptInput.Status.Value = plan.Edges_node_status_value.ValueString()
+		adoptInput.Topology.Id = plan.Edges_node_topology_node_id.ValueString()
+		adoptInput.Tags = relatedNodeInputs(mergeTags(plan.Tags, r.defaultTags))
+
+		// Record the provider lineage on everything written
+		r.lineage.set(&adoptInput.Name.Source, &adoptInput.Name.Owner, &adoptInput.Name.Is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Role.Source, &adoptInput.Role.Owner, &adoptInput.Role.Is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Asn.Relation__source, &adoptInput.Asn.Relation__owner, &adoptInput.Asn.Relation__is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Description.Source, &adoptInput.Description.Owner, &adoptInput.Description.Is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Device_type.Relation__source, &adoptInput.Device_type.Relation__owner, &adoptInput.Device_type.Relation__is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Location.Relation__source, &adoptInput.Location.Relation__owner, &adoptInput.Location.Relation__is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Platform.Relation__source, &adoptInput.Platform.Relation__owner, &adoptInput.Platform.Relation__is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Primary_address.Relation__source, &adoptInput.Primary_address.Relation__owner, &adoptInput.Primary_address.Relation__is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Status.Source, &adoptInput.Status.Owner, &adoptInput.Status.Is_protected, plan.ProtectAttributes)
+		r.lineage.set(&adoptInput.Topology.Relation__source, &adoptInput.Topology.Relation__owner, &adoptInput.Topology.Relation__is_protected, plan.ProtectAttributes)
+		for i := range adoptInput.Tags {
+			r.lineage.set(&adoptInput.Tags[i].Relation__source, &adoptInput.Tags[i].Relation__owner, &adoptInput.Tags[i].Relation__is_protected, plan.ProtectAttributes)
+		}
+
+		response, err := infrahub_sdk.DeviceUpsert(ctx, *r.client, adoptInput)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create or adopt device in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		plan.Edges_node_id = types.StringValue(response.InfraDeviceUpsert.Object.GetId())
+		plan.Edges_node_name_value = types.StringValue(response.InfraDeviceUpsert.Object.Name.Value)
+		plan.Edges_node_role_value = types.StringValue(response.InfraDeviceUpsert.Object.Role.Value)
+		plan.Edges_node_role_id = types.StringValue(response.InfraDeviceUpsert.Object.Role.GetId())
+		plan.Edges_node_asn_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Asn.Node.GetId())
+		plan.Edges_node_description_id = types.StringValue(response.InfraDeviceUpsert.Object.Description.GetId())
+		plan.Edges_node_description_value = types.StringValue(response.InfraDeviceUpsert.Object.Description.Value)
+		plan.Edges_node_device_type_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Device_type.Node.GetId())
+		plan.Edges_node_location_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Location.Node.GetId())
+		plan.Edges_node_platform_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Platform.Node.GetId())
+		plan.Edges_node_primary_address_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Primary_address.Node.GetId())
+		plan.Edges_node_status_id = types.StringValue(response.InfraDeviceUpsert.Object.Status.GetId())
+		plan.Edges_node_status_value = types.StringValue(response.InfraDeviceUpsert.Object.Status.Value)
+		plan.Edges_node_topology_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Topology.Node.GetId())
+		plan.Edges_node_topology_node_name_value = types.StringValue(response.InfraDeviceUpsert.Object.Topology.Node.Name.Value)
+
+		tags := []string{}
+		for _, edge := range response.InfraDeviceUpsert.Object.Tags.Edges {
+			tags = append(tags, edge.Node.Id)
+		}
+		plan.Tags = resourceTags(tags, plan.Tags, r.defaultTags)
+		plan.TagsAll = idSet(tags...)
+		plan.UpdatedAt = updatedAt(response.InfraDeviceUpsert.Object.Updated_at)
+		plan.AttributeOwners = lineageMap(map[string]string{
+			"name_value":        nodeId(response.InfraDeviceUpsert.Object.Name.Owner),
+			"role_value":        nodeId(response.InfraDeviceUpsert.Object.Role.Owner),
+			"description_value": nodeId(response.InfraDeviceUpsert.Object.Description.Owner),
+			"status_value":      nodeId(response.InfraDeviceUpsert.Object.Status.Owner),
+		})
+		plan.AttributeSources = lineageMap(map[string]string{
+			"name_value":        nodeId(response.InfraDeviceUpsert.Object.Name.Source),
+			"role_value":        nodeId(response.InfraDeviceUpsert.Object.Role.Source),
+			"description_value": nodeId(response.InfraDeviceUpsert.Object.Description.Source),
+			"status_value":      nodeId(response.InfraDeviceUpsert.Object.Status.Source),
+		})
+	} else {
+		response, err := infrahub_sdk.DeviceCreate(ctx, *r.client, defaultDevice)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create device in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		plan.Edges_node_id = types.StringValue(response.InfraDeviceCreate.Object.GetId())
+		plan.Edges_node_name_value = types.StringValue(response.InfraDeviceCreate.Object.Name.Value)
+		plan.Edges_node_role_value = types.StringValue(response.InfraDeviceCreate.Object.Role.Value)
+		plan.Edges_node_role_id = types.StringValue(response.InfraDeviceCreate.Object.Role.GetId())
+		plan.Edges_node_asn_node_id = types.StringValue(response.InfraDeviceCreate.Object.Asn.Node.GetId())
+		plan.Edges_node_description_id = types.StringValue(response.InfraDeviceCreate.Object.Description.GetId())
+		plan.Edges_node_description_value = types.StringValue(response.InfraDeviceCreate.Object.Description.Value)
+		plan.Edges_node_device_type_node_id = types.StringValue(response.InfraDeviceCreate.Object.Device_type.Node.GetId())
+		plan.Edges_node_location_node_id = types.StringValue(response.InfraDeviceCreate.Object.Location.Node.GetId())
+		plan.Edges_node_platform_node_id = types.StringValue(response.InfraDeviceCreate.Object.Platform.Node.GetId())
+		plan.Edges_node_primary_address_node_id = types.StringValue(response.InfraDeviceCreate.Object.Primary_address.Node.GetId())
+		plan.Edges_node_status_id = types.StringValue(response.InfraDeviceCreate.Object.Status.GetId())
+		plan.Edges_node_status_value = types.StringValue(response.InfraDeviceCreate.Object.Status.Value)
+		plan.Edges_node_topology_node_id = types.StringValue(response.InfraDeviceCreate.Object.Topology.Node.GetId())
+		plan.Edges_node_topology_node_name_value = types.StringValue(response.InfraDeviceCreate.Object.Topology.Node.Name.Value)
+
+		tags := []string{}
+		for _, edge := range response.InfraDeviceCreate.Object.Tags.Edges {
+			tags = append(tags, edge.Node.Id)
+		}
+		plan.Tags = resourceTags(tags, plan.Tags, r.defaultTags)
+		plan.TagsAll = idSet(tags...)
+		plan.UpdatedAt = updatedAt(response.InfraDeviceCreate.Object.Updated_at)
+		plan.AttributeOwners = lineageMap(map[string]string{
+			"name_value":        nodeId(response.InfraDeviceCreate.Object.Name.Owner),
+			"role_value":        nodeId(response.InfraDeviceCreate.Object.Role.Owner),
+			"description_value": nodeId(response.InfraDeviceCreate.Object.Description.Owner),
+			"status_value":      nodeId(response.InfraDeviceCreate.Object.Status.Owner),
+		})
+		plan.AttributeSources = lineageMap(map[string]string{
+			"name_value":        nodeId(response.InfraDeviceCreate.Object.Name.Source),
+			"role_value":        nodeId(response.InfraDeviceCreate.Object.Role.Source),
+			"description_value": nodeId(response.InfraDeviceCreate.Object.Description.Source),
+			"status_value":      nodeId(response.InfraDeviceCreate.Object.Status.Source),
+		})
 	}
-	plan.Edges_node_id = types.StringValue(response.InfraDeviceCreate.Object.GetId())
-	plan.Edges_node_name_value = types.StringValue(response.InfraDeviceCreate.Object.Name.Value)
-	plan.Edges_node_role_value = types.StringValue(response.InfraDeviceCreate.Object.Role.Value)
-	plan.Edges_node_role_id = types.StringValue(response.InfraDeviceCreate.Object.Role.GetId())
-	plan.Edges_node_asn_node_id = types.StringValue(response.InfraDeviceCreate.Object.Asn.Node.GetId())
-	plan.Edges_node_description_id = types.StringValue(response.InfraDeviceCreate.Object.Description.GetId())
-	plan.Edges_node_description_value = types.StringValue(response.InfraDeviceCreate.Object.Description.Value)
-	plan.Edges_node_device_type_node_id = types.StringValue(response.InfraDeviceCreate.Object.Device_type.Node.GetId())
-	plan.Edges_node_location_node_id = types.StringValue(response.InfraDeviceCreate.Object.Location.Node.GetId())
-	plan.Edges_node_platform_node_id = types.StringValue(response.InfraDeviceCreate.Object.Platform.Node.GetId())
-	plan.Edges_node_primary_address_node_id = types.StringValue(response.InfraDeviceCreate.Object.Primary_address.Node.GetId())
-	plan.Edges_node_status_id = types.StringValue(response.InfraDeviceCreate.Object.Status.GetId())
-	plan.Edges_node_status_value = types.StringValue(response.InfraDeviceCreate.Object.Status.Value)
-	plan.Edges_node_topology_node_id = types.StringValue(response.InfraDeviceCreate.Object.Topology.Node.GetId())
-	plan.Edges_node_topology_node_name_value = types.StringValue(response.InfraDeviceCreate.Object.Topology.Node.Name.Value)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -221,6 +354,26 @@ func (r *deviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.Edges_node_topology_node_id = types.StringValue(response.InfraDevice.Edges[0].Node.Topology.Node.GetId())
 	state.Edges_node_topology_node_name_value = types.StringValue(response.InfraDevice.Edges[0].Node.Topology.Node.Name.Value)
 
+	tags := []string{}
+	for _, edge := range response.InfraDevice.Edges[0].Node.Tags.Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+	state.Tags = resourceTags(tags, state.Tags, r.defaultTags)
+	state.TagsAll = idSet(tags...)
+	state.UpdatedAt = updatedAt(response.InfraDevice.Edges[0].Node.Updated_at)
+	state.AttributeOwners = lineageMap(map[string]string{
+		"name_value":        nodeId(response.InfraDevice.Edges[0].Node.Name.Owner),
+		"role_value":        nodeId(response.InfraDevice.Edges[0].Node.Role.Owner),
+		"description_value": nodeId(response.InfraDevice.Edges[0].Node.Description.Owner),
+		"status_value":      nodeId(response.InfraDevice.Edges[0].Node.Status.Owner),
+	})
+	state.AttributeSources = lineageMap(map[string]string{
+		"name_value":        nodeId(response.InfraDevice.Edges[0].Node.Name.Source),
+		"role_value":        nodeId(response.InfraDevice.Edges[0].Node.Role.Source),
+		"description_value": nodeId(response.InfraDevice.Edges[0].Node.Description.Source),
+		"status_value":      nodeId(response.InfraDevice.Edges[0].Node.Status.Source),
+	})
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -246,7 +399,60 @@ func (r *deviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var updateInput infrahub_sdk.InfraDeviceUpsertInput
+	// Refuse to overwrite changes made in Infrahub since the plan was made.
+	// The node is read by ID, so that a rename does not hide the changes.
+	current, err := infrahub_sdk.DeviceById(ctx, *r.client, state.Edges_node_id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read device from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	if len(current.InfraDevice.Edges) == 0 {
+		resp.Diagnostics.AddError(
+			"InfraDevice no longer exists in Infrahub",
+			fmt.Sprintf("InfraDevice %s was deleted in Infrahub after the plan was made. Run terraform plan again to create it.", state.Edges_node_id.ValueString()),
+		)
+		return
+	}
+	changed := []string{}
+	if current.InfraDevice.Edges[0].Node.Name.Value != state.Edges_node_name_value.ValueString() {
+		changed = append(changed, "name_value")
+	}
+	if current.InfraDevice.Edges[0].Node.Role.Value != state.Edges_node_role_value.ValueString() {
+		changed = append(changed, "role_value")
+	}
+	if current.InfraDevice.Edges[0].Node.Asn.Node.GetId() != state.Edges_node_asn_node_id.ValueString() {
+		changed = append(changed, "asn_node_id")
+	}
+	if current.InfraDevice.Edges[0].Node.Description.Value != state.Edges_node_description_value.ValueString() {
+		changed = append(changed, "description_value")
+	}
+	if current.InfraDevice.Edges[0].Node.Device_type.Node.GetId() != state.Edges_node_device_type_node_id.ValueString() {
+		changed = append(changed, "device_type_node_id")
+	}
+	if current.InfraDevice.Edges[0].Node.Location.Node.GetId() != state.Edges_node_location_node_id.ValueString() {
+		changed = append(changed, "location_node_id")
+	}
+	if current.InfraDevice.Edges[0].Node.Platform.Node.GetId() != state.Edges_node_platform_node_id.ValueString() {
+		changed = append(changed, "platform_node_id")
+	}
+	if current.InfraDevice.Edges[0].Node.Primary_address.Node.GetId() != state.Edges_node_primary_address_node_id.ValueString() {
+		changed = append(changed, "primary_address_node_id")
+	}
+	if current.InfraDevice.Edges[0].Node.Status.Value != state.Edges_node_status_value.ValueString() {
+		changed = append(changed, "status_value")
+	}
+	if current.InfraDevice.Edges[0].Node.Topology.Node.GetId() != state.Edges_node_topology_node_id.ValueString() {
+		changed = append(changed, "topology_node_id")
+	}
+	resp.Diagnostics.Append(checkConflict(r.conflictMode, "InfraDevice", state.Edges_node_id.ValueString(), state.UpdatedAt, current.InfraDevice.Edges[0].Node.Updated_at, changed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var updateInput infrahub_sdk.InfraDeviceUpdateInput
 
 	// Prepare the update input using values from the plan and applying defaults
 	updateInput.Name.Value = setDefault(plan.Edges_node_name_value.ValueString(), state.Edges_node_name_value.ValueString())
@@ -260,12 +466,28 @@ func (r *deviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	updateInput.Status.Value = setDefault(plan.Edges_node_status_value.ValueString(), state.Edges_node_status_value.ValueString())
 	updateInput.Topology.Id = setDefault(plan.Edges_node_topology_node_id.ValueString(), state.Edges_node_topology_node_id.ValueString())
 	updateInput.Id = state.Edges_node_id.ValueString()
+	updateInput.Tags = relatedNodeInputs(mergeTags(plan.Tags, r.defaultTags))
+
+	// Record the provider lineage on everything written
+	r.lineage.set(&updateInput.Name.Source, &updateInput.Name.Owner, &updateInput.Name.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Role.Source, &updateInput.Role.Owner, &updateInput.Role.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Asn.Relation__source, &updateInput.Asn.Relation__owner, &updateInput.Asn.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Description.Source, &updateInput.Description.Owner, &updateInput.Description.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Device_type.Relation__source, &updateInput.Device_type.Relation__owner, &updateInput.Device_type.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Location.Relation__source, &updateInput.Location.Relation__owner, &updateInput.Location.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Platform.Relation__source, &updateInput.Platform.Relation__owner, &updateInput.Platform.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Primary_address.Relation__source, &updateInput.Primary_address.Relation__owner, &updateInput.Primary_address.Relation__is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Status.Source, &updateInput.Status.Owner, &updateInput.Status.Is_protected, plan.ProtectAttributes)
+	r.lineage.set(&updateInput.Topology.Relation__source, &updateInput.Topology.Relation__owner, &updateInput.Topology.Relation__is_protected, plan.ProtectAttributes)
+	for i := range updateInput.Tags {
+		r.lineage.set(&updateInput.Tags[i].Relation__source, &updateInput.Tags[i].Relation__owner, &updateInput.Tags[i].Relation__is_protected, plan.ProtectAttributes)
+	}
 
 	// Log the update operation
 	tflog.Info(ctx, fmt.Sprintf("Updating Device %s", state.Edges_node_name_value.ValueString()))
 
 	// Send the update request to the API
-	response, err := infrahub_sdk.DeviceUpsert(ctx, *r.client, updateInput)
+	response, err := infrahub_sdk.DeviceUpdate(ctx, *r.client, updateInput)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update device in Infrahub",
@@ -273,21 +495,41 @@ func (r *deviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
-	plan.Edges_node_id = types.StringValue(response.InfraDeviceUpsert.Object.GetId())
-	plan.Edges_node_name_value = types.StringValue(response.InfraDeviceUpsert.Object.Name.Value)
-	plan.Edges_node_role_value = types.StringValue(response.InfraDeviceUpsert.Object.Role.Value)
-	plan.Edges_node_role_id = types.StringValue(response.InfraDeviceUpsert.Object.Role.GetId())
-	plan.Edges_node_asn_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Asn.Node.GetId())
-	plan.Edges_node_description_id = types.StringValue(response.InfraDeviceUpsert.Object.Description.GetId())
-	plan.Edges_node_description_value = types.StringValue(response.InfraDeviceUpsert.Object.Description.Value)
-	plan.Edges_node_device_type_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Device_type.Node.GetId())
-	plan.Edges_node_location_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Location.Node.GetId())
-	plan.Edges_node_platform_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Platform.Node.GetId())
-	plan.Edges_node_primary_address_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Primary_address.Node.GetId())
-	plan.Edges_node_status_id = types.StringValue(response.InfraDeviceUpsert.Object.Status.GetId())
-	plan.Edges_node_status_value = types.StringValue(response.InfraDeviceUpsert.Object.Status.Value)
-	plan.Edges_node_topology_node_id = types.StringValue(response.InfraDeviceUpsert.Object.Topology.Node.GetId())
-	plan.Edges_node_topology_node_name_value = types.StringValue(response.InfraDeviceUpsert.Object.Topology.Node.Name.Value)
+	plan.Edges_node_id = types.StringValue(response.InfraDeviceUpdate.Object.GetId())
+	plan.Edges_node_name_value = types.StringValue(response.InfraDeviceUpdate.Object.Name.Value)
+	plan.Edges_node_role_value = types.StringValue(response.InfraDeviceUpdate.Object.Role.Value)
+	plan.Edges_node_role_id = types.StringValue(response.InfraDeviceUpdate.Object.Role.GetId())
+	plan.Edges_node_asn_node_id = types.StringValue(response.InfraDeviceUpdate.Object.Asn.Node.GetId())
+	plan.Edges_node_description_id = types.StringValue(response.InfraDeviceUpdate.Object.Description.GetId())
+	plan.Edges_node_description_value = types.StringValue(response.InfraDeviceUpdate.Object.Description.Value)
+	plan.Edges_node_device_type_node_id = types.StringValue(response.InfraDeviceUpdate.Object.Device_type.Node.GetId())
+	plan.Edges_node_location_node_id = types.StringValue(response.InfraDeviceUpdate.Object.Location.Node.GetId())
+	plan.Edges_node_platform_node_id = types.StringValue(response.InfraDeviceUpdate.Object.Platform.Node.GetId())
+	plan.Edges_node_primary_address_node_id = types.StringValue(response.InfraDeviceUpdate.Object.Primary_address.Node.GetId())
+	plan.Edges_node_status_id = types.StringValue(response.InfraDeviceUpdate.Object.Status.GetId())
+	plan.Edges_node_status_value = types.StringValue(response.InfraDeviceUpdate.Object.Status.Value)
+	plan.Edges_node_topology_node_id = types.StringValue(response.InfraDeviceUpdate.Object.Topology.Node.GetId())
+	plan.Edges_node_topology_node_name_value = types.StringValue(response.InfraDeviceUpdate.Object.Topology.Node.Name.Value)
+
+	tags := []string{}
+	for _, edge := range response.InfraDeviceUpdate.Object.Tags.Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+	plan.Tags = resourceTags(tags, plan.Tags, r.defaultTags)
+	plan.TagsAll = idSet(tags...)
+	plan.UpdatedAt = updatedAt(response.InfraDeviceUpdate.Object.Updated_at)
+	plan.AttributeOwners = lineageMap(map[string]string{
+		"name_value":        nodeId(response.InfraDeviceUpdate.Object.Name.Owner),
+		"role_value":        nodeId(response.InfraDeviceUpdate.Object.Role.Owner),
+		"description_value": nodeId(response.InfraDeviceUpdate.Object.Description.Owner),
+		"status_value":      nodeId(response.InfraDeviceUpdate.Object.Status.Owner),
+	})
+	plan.AttributeSources = lineageMap(map[string]string{
+		"name_value":        nodeId(response.InfraDeviceUpdate.Object.Name.Source),
+		"role_value":        nodeId(response.InfraDeviceUpdate.Object.Role.Source),
+		"description_value": nodeId(response.InfraDeviceUpdate.Object.Description.Source),
+		"status_value":      nodeId(response.InfraDeviceUpdate.Object.Status.Source),
+	})
 
 	// Set the updated state with the latest data
 	diags = resp.State.Set(ctx, &plan)
@@ -335,4 +577,23 @@ func (r *deviceResource) Configure(_ context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = &client
+	r.lineage = providerLineage(req.ProviderData)
+	r.conflictMode = providerConflictMode(req.ProviderData)
+	r.choices = providerSchemaChoices(req.ProviderData)
+	r.defaultTags = providerDefaultTags(req.ProviderData)
+}
+
+// ModifyPlan checks the dropdown values against the schema choices and adds the provider default tags to tags_all.
+func (r *deviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "InfraDevice", map[string]string{
+		"role_value":   "role",
+		"status_value": "status",
+	})...)
+
+	resp.Diagnostics.Append(planTagsAll(ctx, req, resp, r.defaultTags)...)
 }
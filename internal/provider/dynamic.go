@@ -0,0 +1,348 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// kindPattern matches the name of a kind, which is also the name of its
+// GraphQL query and the prefix of its mutations.
+var kindPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// namePattern matches the name of an attribute or a relationship, which is
+// also the name of its GraphQL field.
+var namePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// cardinalityOne is the cardinality of relationships with a single peer.
+const cardinalityOne = "one"
+
+// graphqlRequest sends a query built at runtime, for the kinds without
+// generated operations, and decodes its data into v. Numbers are decoded as
+// json.Number to keep their precision.
+func graphqlRequest(ctx context.Context, client graphql.Client, opName string, query string, variables map[string]any, v any) error {
+	var data json.RawMessage
+	err := client.MakeRequest(ctx,
+		&graphql.Request{OpName: opName, Query: query, Variables: variables},
+		&graphql.Response{Data: &data},
+	)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("unable to decode the response of %s: %w", opName, err)
+	}
+	return nil
+}
+
+// nodeSelection returns the GraphQL selection of a node with the value of
+// the attributes and the peer IDs of the relationships. Names missing from
+// the schema of the node are left out.
+func nodeSelection(node *schemaNode, attributes []string, relationships []string) string {
+	fields := []string{"id", "hfid", "display_label"}
+	for _, name := range attributes {
+		if node.hasAttribute(name) {
+			fields = append(fields, name+" { value }")
+		}
+	}
+	for _, name := range relationships {
+		relationship := node.relationship(name)
+		switch {
+		case relationship == nil:
+		case relationship.Cardinality == cardinalityOne:
+			fields = append(fields, name+" { node { id } }")
+		default:
+			fields = append(fields, name+" { edges { node { id } } }")
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// hasAttribute reports whether the node has an attribute.
+func (n *schemaNode) hasAttribute(name string) bool {
+	for _, attribute := range n.Attributes {
+		if attribute.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// relationship returns a relationship of the node, nil when it does not
+// exist.
+func (n *schemaNode) relationship(name string) *schemaRelationship {
+	for i := range n.Relationships {
+		if n.Relationships[i].Name == name {
+			return &n.Relationships[i]
+		}
+	}
+	return nil
+}
+
+// attributeValue returns the value of an attribute in a node selected by
+// nodeSelection.
+func attributeValue(node map[string]any, name string) any {
+	if field, ok := node[name].(map[string]any); ok {
+		return field["value"]
+	}
+	return nil
+}
+
+// relationshipIDs returns the peer IDs of a relationship in a node selected
+// by nodeSelection.
+func relationshipIDs(node map[string]any, name string) []string {
+	ids := []string{}
+	field, _ := node[name].(map[string]any)
+	if peer, ok := field["node"].(map[string]any); ok {
+		if id, ok := peer["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	edges, _ := field["edges"].([]any)
+	for _, edge := range edges {
+		peer, _ := edge.(map[string]any)["node"].(map[string]any)
+		if id, ok := peer["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// dynamicElements returns the elements of a dynamic value holding an object
+// or a map, empty when it is null or unknown.
+func dynamicElements(value types.Dynamic) (map[string]attr.Value, error) {
+	if value.IsNull() || value.IsUnknown() || value.IsUnderlyingValueNull() || value.IsUnderlyingValueUnknown() {
+		return map[string]attr.Value{}, nil
+	}
+	switch v := value.UnderlyingValue().(type) {
+	case basetypes.ObjectValue:
+		return v.Attributes(), nil
+	case basetypes.MapValue:
+		return v.Elements(), nil
+	}
+	return nil, fmt.Errorf("expected an object or a map, got %s", value.UnderlyingValue().Type(context.Background()))
+}
+
+// jsonValue converts a Terraform value into the value sent to the GraphQL
+// API.
+func jsonValue(value attr.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("the value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return jsonValue(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		return json.Number(v.ValueBigFloat().Text('f', -1)), nil
+	case basetypes.Int64Value:
+		return v.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.ListValue:
+		return jsonValues(v.Elements())
+	case basetypes.SetValue:
+		return jsonValues(v.Elements())
+	case basetypes.TupleValue:
+		return jsonValues(v.Elements())
+	case basetypes.MapValue:
+		return jsonObject(v.Elements())
+	case basetypes.ObjectValue:
+		return jsonObject(v.Attributes())
+	}
+	return nil, fmt.Errorf("unsupported value of type %s", value.Type(context.Background()))
+}
+
+// jsonValues converts the elements of a Terraform collection.
+func jsonValues(elements []attr.Value) (any, error) {
+	values := []any{}
+	for _, element := range elements {
+		value, err := jsonValue(element)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// jsonObject converts the attributes of a Terraform object or map.
+func jsonObject(attributes map[string]attr.Value) (any, error) {
+	object := map[string]any{}
+	for name, attribute := range attributes {
+		value, err := jsonValue(attribute)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		object[name] = value
+	}
+	return object, nil
+}
+
+// terraformValue converts a value returned by the GraphQL API into a
+// Terraform value. Lists become tuples and objects become objects, so that
+// their elements keep their own types.
+func terraformValue(value any) attr.Value {
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.BoolValue(v)
+	case json.Number:
+		if f, _, err := big.ParseFloat(string(v), 10, 512, big.ToNearestEven); err == nil {
+			return types.NumberValue(f)
+		}
+		return types.StringValue(string(v))
+	case []any:
+		elementTypes := []attr.Type{}
+		elements := []attr.Value{}
+		for _, item := range v {
+			element := terraformValue(item)
+			elementTypes = append(elementTypes, element.Type(context.Background()))
+			elements = append(elements, element)
+		}
+		return types.TupleValueMust(elementTypes, elements)
+	case map[string]any:
+		attributeTypes := map[string]attr.Type{}
+		attributes := map[string]attr.Value{}
+		for name, item := range v {
+			attribute := terraformValue(item)
+			attributeTypes[name] = attribute.Type(context.Background())
+			attributes[name] = attribute
+		}
+		return types.ObjectValueMust(attributeTypes, attributes)
+	}
+	// Null values have no type, nodes mostly hold strings
+	return types.StringNull()
+}
+
+// refreshedValue converts a value returned by the GraphQL API into a
+// Terraform value of the type of the prior value, so that refreshing an
+// unchanged value does not show a difference. Values that do not fit the
+// prior type keep the type of terraformValue.
+func refreshedValue(prior attr.Value, value any) attr.Value {
+	ctx := context.Background()
+	if dynamic, ok := prior.(basetypes.DynamicValue); ok {
+		if dynamic.IsUnderlyingValueNull() || dynamic.IsUnderlyingValueUnknown() {
+			return types.DynamicValue(terraformValue(value))
+		}
+		return types.DynamicValue(refreshedValue(dynamic.UnderlyingValue(), value))
+	}
+
+	current := terraformValue(value)
+	if prior == nil {
+		return current
+	}
+	priorType := prior.Type(ctx)
+	if value == nil {
+		if null, err := priorType.ValueFromTerraform(ctx, tftypes.NewValue(priorType.TerraformType(ctx), nil)); err == nil {
+			return null
+		}
+		return current
+	}
+	if current.Type(ctx).Equal(priorType) {
+		return current
+	}
+
+	switch p := prior.(type) {
+	case basetypes.StringValue:
+		switch v := value.(type) {
+		case json.Number:
+			return types.StringValue(string(v))
+		case bool:
+			return types.StringValue(fmt.Sprint(v))
+		}
+	case basetypes.Int64Value:
+		if n, ok := value.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				return types.Int64Value(i)
+			}
+		}
+	case basetypes.Float64Value:
+		if n, ok := value.(json.Number); ok {
+			if f, err := n.Float64(); err == nil {
+				return types.Float64Value(f)
+			}
+		}
+	case basetypes.ListValue:
+		if elements, ok := refreshedElements(p.ElementType(ctx), value); ok {
+			return types.ListValueMust(p.ElementType(ctx), elements)
+		}
+	case basetypes.SetValue:
+		if elements, ok := refreshedElements(p.ElementType(ctx), value); ok {
+			return types.SetValueMust(p.ElementType(ctx), elements)
+		}
+	case basetypes.MapValue:
+		if items, ok := value.(map[string]any); ok {
+			elements := map[string]attr.Value{}
+			for name, item := range items {
+				element := refreshedValue(p.Elements()[name], item)
+				if !element.Type(ctx).Equal(p.ElementType(ctx)) {
+					return current
+				}
+				elements[name] = element
+			}
+			return types.MapValueMust(p.ElementType(ctx), elements)
+		}
+	case basetypes.ObjectValue:
+		if items, ok := value.(map[string]any); ok {
+			attributeTypes := map[string]attr.Type{}
+			attributes := map[string]attr.Value{}
+			for name, item := range items {
+				attribute := refreshedValue(p.Attributes()[name], item)
+				attributeTypes[name] = attribute.Type(ctx)
+				attributes[name] = attribute
+			}
+			return types.ObjectValueMust(attributeTypes, attributes)
+		}
+	}
+	return current
+}
+
+// refreshedElements converts the items of a list returned by the GraphQL
+// API into elements of a Terraform collection, ok is false when an item does
+// not fit the element type.
+func refreshedElements(elementType attr.Type, value any) ([]attr.Value, bool) {
+	ctx := context.Background()
+	items, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	// A null value of the element type guides the conversion of the items
+	null, err := elementType.ValueFromTerraform(ctx, tftypes.NewValue(elementType.TerraformType(ctx), nil))
+	if err != nil {
+		return nil, false
+	}
+	elements := []attr.Value{}
+	for _, item := range items {
+		element := refreshedValue(null, item)
+		if !element.Type(ctx).Equal(elementType) {
+			return nil, false
+		}
+		elements = append(elements, element)
+	}
+	return elements, true
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJsonValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     attr.Value
+		want      any
+		wantError bool
+	}{
+		{name: "null", value: types.StringNull(), want: nil},
+		{name: "string", value: types.StringValue("dmz"), want: "dmz"},
+		{name: "bool", value: types.BoolValue(true), want: true},
+		{name: "number", value: types.NumberValue(big.NewFloat(4094)), want: json.Number("4094")},
+		{name: "decimal", value: types.NumberValue(big.NewFloat(1.5)), want: json.Number("1.5")},
+		{name: "int64", value: types.Int64Value(65000), want: int64(65000)},
+		{
+			name:  "dynamic tuple",
+			value: types.DynamicValue(types.TupleValueMust([]attr.Type{types.StringType, types.BoolType}, []attr.Value{types.StringValue("a"), types.BoolValue(false)})),
+			want:  []any{"a", false},
+		},
+		{
+			name: "object",
+			value: types.ObjectValueMust(
+				map[string]attr.Type{"name": types.StringType, "mtu": types.NumberType},
+				map[string]attr.Value{"name": types.StringValue("eth0"), "mtu": types.NumberNull()},
+			),
+			want: map[string]any{"name": "eth0", "mtu": nil},
+		},
+		{name: "unknown", value: types.StringUnknown(), wantError: true},
+		{
+			name:      "unknown in a map",
+			value:     types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringUnknown()}),
+			wantError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := jsonValue(test.value)
+			if (err != nil) != test.wantError {
+				t.Fatalf("jsonValue() error = %v, want error %t", err, test.wantError)
+			}
+			if !test.wantError && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("jsonValue() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTerraformValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  attr.Value
+	}{
+		{name: "null", value: nil, want: types.StringNull()},
+		{name: "string", value: "dmz", want: types.StringValue("dmz")},
+		{name: "number", value: json.Number("4094"), want: types.NumberValue(big.NewFloat(4094))},
+		{
+			name:  "list",
+			value: []any{"a", true},
+			want:  types.TupleValueMust([]attr.Type{types.StringType, types.BoolType}, []attr.Value{types.StringValue("a"), types.BoolValue(true)}),
+		},
+		{
+			name:  "object",
+			value: map[string]any{"name": "eth0"},
+			want:  types.ObjectValueMust(map[string]attr.Type{"name": types.StringType}, map[string]attr.Value{"name": types.StringValue("eth0")}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := terraformValue(test.value); !got.Equal(test.want) {
+				t.Errorf("terraformValue() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRefreshedValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		prior attr.Value
+		value any
+		want  attr.Value
+	}{
+		{name: "no prior value", prior: nil, value: "dmz", want: types.StringValue("dmz")},
+		{name: "same type", prior: types.StringValue("dmz"), value: "lan", want: types.StringValue("lan")},
+		{name: "number as string", prior: types.StringValue("100"), value: json.Number("100"), want: types.StringValue("100")},
+		{name: "bool as string", prior: types.StringValue("true"), value: true, want: types.StringValue("true")},
+		{name: "int64", prior: types.Int64Value(1), value: json.Number("4094"), want: types.Int64Value(4094)},
+		{name: "float64", prior: types.Float64Value(1), value: json.Number("1.5"), want: types.Float64Value(1.5)},
+		{name: "null of the prior type", prior: types.Int64Value(1), value: nil, want: types.Int64Null()},
+		{
+			name:  "list of strings",
+			prior: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+			value: []any{"a", "b"},
+			want:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}),
+		},
+		{
+			name:  "set of numbers",
+			prior: types.SetValueMust(types.Int64Type, []attr.Value{}),
+			value: []any{json.Number("1"), json.Number("2")},
+			want:  types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(1), types.Int64Value(2)}),
+		},
+		{
+			name:  "list not fitting the element type",
+			prior: types.ListValueMust(types.Int64Type, []attr.Value{}),
+			value: []any{"a"},
+			want:  types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("a")}),
+		},
+		{
+			name:  "map",
+			prior: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+			value: map[string]any{"site": "fra05"},
+			want:  types.MapValueMust(types.StringType, map[string]attr.Value{"site": types.StringValue("fra05")}),
+		},
+		{
+			name:  "dynamic",
+			prior: types.DynamicValue(types.Int64Value(1)),
+			value: json.Number("2"),
+			want:  types.DynamicValue(types.Int64Value(2)),
+		},
+		{
+			name:  "null dynamic",
+			prior: types.DynamicNull(),
+			value: "dmz",
+			want:  types.DynamicValue(types.StringValue("dmz")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := refreshedValue(test.prior, test.value); !got.Equal(test.want) {
+				t.Errorf("refreshedValue() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &groupMembersResource{}
+	_ resource.ResourceWithConfigure   = &groupMembersResource{}
+	_ resource.ResourceWithImportState = &groupMembersResource{}
+)
+
+// NewGroupMembersResource is a helper function to simplify the provider implementation.
+func NewGroupMembersResource() resource.Resource {
+	return &groupMembersResource{}
+}
+
+// groupMembersResource is the resource implementation.
+type groupMembersResource struct {
+	client  *graphql.Client
+	Id      types.String `tfsdk:"id"`
+	GroupId types.String `tfsdk:"group_id"`
+	Members types.Set    `tfsdk:"members"`
+}
+
+// Metadata returns the resource type name.
+func (r *groupMembersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_members"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupMembersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all members of a `CoreStandardGroup`. Members added outside of this resource are " +
+			"removed on the next apply, do not combine it with `infrahub_group_member` on the same group. Import with the group ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the group",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "IDs of the member nodes, of any kind",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *groupMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan groupMembersResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Setting members of group ", plan.GroupId))
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *groupMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading group members...")
+	var state groupMembersResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, found, err := groupMembers(ctx, *r.client, state.Id.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read group members from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The group was deleted outside of Terraform
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.GroupId = state.Id
+	state.Members = idSet(members...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *groupMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan groupMembersResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating members of group %s", plan.GroupId.ValueString()))
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the members managed by the resource from the group.
+func (r *groupMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state groupMembersResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(state.Members.Elements()) == 0 {
+		return
+	}
+
+	_, err := infrahub_sdk.RelationshipRemove(ctx, *r.client, state.GroupId.ValueString(), "members", relatedNodes(state.Members))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting group members",
+			"Could not remove members from group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the members of a group by the group ID.
+func (r *groupMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// sync adds and removes members so the group holds exactly the planned
+// members. The difference is computed against Infrahub rather than the
+// prior state, so members added elsewhere are removed as well.
+func (r *groupMembersResource) sync(ctx context.Context, plan *groupMembersResource) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	current, found, err := groupMembers(ctx, *r.client, plan.GroupId.ValueString(), nil)
+	if err != nil {
+		diags.AddError("Unable to read group members from Infrahub", err.Error())
+		return diags
+	}
+	if !found {
+		diags.AddError("Group not found", fmt.Sprintf("No group with ID %s exists in Infrahub", plan.GroupId.ValueString()))
+		return diags
+	}
+
+	planned := map[string]bool{}
+	for _, node := range relatedNodes(plan.Members) {
+		planned[node.Id] = true
+	}
+	existing := map[string]bool{}
+	removed := infrahub_sdk.RelatedNodes{}
+	for _, id := range current {
+		existing[id] = true
+		if !planned[id] {
+			removed = append(removed, infrahub_sdk.RelatedNode{Id: id})
+		}
+	}
+	added := infrahub_sdk.RelatedNodes{}
+	for _, node := range relatedNodes(plan.Members) {
+		if !existing[node.Id] {
+			added = append(added, node)
+		}
+	}
+
+	if len(added) > 0 {
+		if _, err := infrahub_sdk.RelationshipAdd(ctx, *r.client, plan.GroupId.ValueString(), "members", added); err != nil {
+			diags.AddError("Unable to add group members in Infrahub", err.Error())
+			return diags
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := infrahub_sdk.RelationshipRemove(ctx, *r.client, plan.GroupId.ValueString(), "members", removed); err != nil {
+			diags.AddError("Unable to remove group members in Infrahub", err.Error())
+			return diags
+		}
+	}
+
+	plan.Id = plan.GroupId
+	return diags
+}
+
+// groupMembersPageSize is the number of members read per query, the server
+// caps the size of a page.
+const groupMembersPageSize = 100
+
+// groupMembers returns the IDs of the members of a group, limited to
+// memberIds unless it is nil. found is false when the group does not exist.
+// The members are read page by page, so that a sync sees all of them.
+func groupMembers(ctx context.Context, client graphql.Client, groupId string, memberIds []string) (members []string, found bool, err error) {
+	members = []string{}
+	for {
+		response, err := infrahub_sdk.GroupMembers(ctx, client, groupId, memberIds, len(members), groupMembersPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(response.CoreStandardGroup.Edges) == 0 {
+			return nil, false, nil
+		}
+
+		page := response.CoreStandardGroup.Edges[0].Node.Members
+		for _, edge := range page.Edges {
+			members = append(members, nodeId(edge.Node))
+		}
+		// Stop on the last page, also when members are removed while reading
+		if len(page.Edges) == 0 || len(members) >= page.Count {
+			break
+		}
+	}
+	return members, true, nil
+}
@@ -0,0 +1,361 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &interfaceL2Resource{}
+	_ resource.ResourceWithConfigure   = &interfaceL2Resource{}
+	_ resource.ResourceWithImportState = &interfaceL2Resource{}
+	_ resource.ResourceWithModifyPlan  = &interfaceL2Resource{}
+)
+
+// NewInterfaceL2Resource is a helper function to simplify the provider implementation.
+func NewInterfaceL2Resource() resource.Resource {
+	return &interfaceL2Resource{}
+}
+
+// interfaceL2Resource is the resource implementation.
+type interfaceL2Resource struct {
+	client         *graphql.Client
+	defaultTags    []string
+	choices        *schemaChoices
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Speed          types.Int64  `tfsdk:"speed"`
+	Mtu            types.Int64  `tfsdk:"mtu"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Status         types.String `tfsdk:"status"`
+	Role           types.String `tfsdk:"role"`
+	DeviceId       types.String `tfsdk:"device_id"`
+	Tags           types.Set    `tfsdk:"tags"`
+	TagsAll        types.Set    `tfsdk:"tags_all"`
+	L2Mode         types.String `tfsdk:"l2_mode"`
+	UntaggedVlanId types.String `tfsdk:"untagged_vlan_id"`
+	TaggedVlans    types.Set    `tfsdk:"tagged_vlans"`
+}
+
+// Metadata returns the resource type name.
+func (r *interfaceL2Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_interface_l2"
+}
+
+// Schema defines the schema for the resource.
+func (r *interfaceL2Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := interfaceSchemaAttributes()
+	attributes["l2_mode"] = schema.StringAttribute{
+		MarkdownDescription: "Switchport mode, e.g. `Access` or `Trunk`",
+		Required:            true,
+	}
+	attributes["untagged_vlan_id"] = schema.StringAttribute{
+		MarkdownDescription: "ID of the untagged (access or native) VLAN",
+		Computed:            true,
+		Optional:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["tagged_vlans"] = schema.SetAttribute{
+		MarkdownDescription: "IDs of the VLANs tagged on the interface",
+		ElementType:         types.StringType,
+		Computed:            true,
+		Optional:            true,
+		PlanModifiers: []planmodifier.Set{
+			setplanmodifier.UseStateForUnknown(),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraInterfaceL2`. Import with the node ID or `<device_id>/<name>`.",
+		Attributes:          attributes,
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *interfaceL2Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan interfaceL2Resource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating InterfaceL2 ", plan.Name))
+
+	response, err := infrahub_sdk.InterfaceL2Create(
+		ctx,
+		*r.client,
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		int64String(plan.Speed),
+		int64String(plan.Mtu),
+		plan.Enabled.ValueBoolPointer(),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.DeviceId.ValueString(),
+		relatedNodes(mergeTags(plan.Tags, r.defaultTags)),
+		plan.L2Mode.ValueString(),
+		plan.UntaggedVlanId.ValueString(),
+		relatedNodes(plan.TaggedVlans),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create interface in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraInterfaceL2Create.Object.InterfaceL2Fields, r.defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *interfaceL2Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading InterfaceL2...")
+	var state interfaceL2Resource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.InterfaceL2(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read interface from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The interface was deleted outside of Terraform
+	if len(response.InfraInterfaceL2.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fill(response.InfraInterfaceL2.Edges[0].Node.InterfaceL2Fields, r.defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *interfaceL2Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan interfaceL2Resource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state interfaceL2Resource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating InterfaceL2 %s", state.Name.ValueString()))
+
+	response, err := infrahub_sdk.InterfaceL2Update(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		int64String(plan.Speed),
+		setDefault(int64String(plan.Mtu), int64String(state.Mtu)),
+		plan.Enabled.ValueBoolPointer(),
+		setDefault(plan.Status.ValueString(), state.Status.ValueString()),
+		setDefault(plan.Role.ValueString(), state.Role.ValueString()),
+		plan.DeviceId.ValueString(),
+		relatedNodes(mergeTags(plan.Tags, r.defaultTags)),
+		plan.L2Mode.ValueString(),
+		infrahub_sdk.RelatedNode{Id: plan.UntaggedVlanId.ValueString()},
+		relatedNodes(plan.TaggedVlans),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update interface in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraInterfaceL2Update.Object.InterfaceL2Fields, r.defaultTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *interfaceL2Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state interfaceL2Resource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.InterfaceL2Delete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting InterfaceL2",
+			"Could not delete interface, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an interface by its node ID or by <device_id>/<name>.
+func (r *interfaceL2Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+
+	if deviceId, name, ok := strings.Cut(req.ID, "/"); ok {
+		response, err := infrahub_sdk.InterfaceL2Lookup(ctx, *r.client, deviceId, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read interface from Infrahub",
+				err.Error(),
+			)
+			return
+		}
+
+		if len(response.InfraInterfaceL2.Edges) != 1 {
+			resp.Diagnostics.AddError(
+				"Didn't receive a single interface, query didn't return exactly 1 interface",
+				fmt.Sprintf("Expected exactly 1 interface %s on device %s, got %d.", name, deviceId, len(response.InfraInterfaceL2.Edges)),
+			)
+			return
+		}
+
+		id = response.InfraInterfaceL2.Edges[0].Node.Id
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *interfaceL2Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.defaultTags = providerDefaultTags(req.ProviderData)
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// ModifyPlan checks the dropdown values against the schema choices and adds
+// the provider default tags to tags_all.
+func (r *interfaceL2Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "InfraInterfaceL2", map[string]string{
+		"status": "status",
+		"role":   "role",
+	})...)
+
+	resp.Diagnostics.Append(planTagsAll(ctx, req, resp, r.defaultTags)...)
+}
+
+// fill copies the fields returned by Infrahub into the resource model,
+// leaving the default tags out of tags.
+func (r *interfaceL2Resource) fill(fields infrahub_sdk.InterfaceL2Fields, defaultTags []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	if r.Speed, err = int64Value(fields.Speed.Value); err != nil {
+		diags.AddError("Unable to parse speed returned by Infrahub", err.Error())
+	}
+	if r.Mtu, err = int64Value(fields.Mtu.Value); err != nil {
+		diags.AddError("Unable to parse mtu returned by Infrahub", err.Error())
+	}
+
+	tags := []string{}
+	for _, edge := range fields.Tags.Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+	taggedVlans := []string{}
+	for _, edge := range fields.Tagged_vlan.Edges {
+		taggedVlans = append(taggedVlans, edge.Node.Id)
+	}
+
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.Enabled = types.BoolValue(fields.Enabled.Value)
+	r.Status = types.StringValue(fields.Status.Value)
+	r.Role = types.StringValue(fields.Role.Value)
+	r.DeviceId = types.StringValue(nodeId(fields.Device.Node))
+	r.Tags = resourceTags(tags, r.Tags, defaultTags)
+	r.TagsAll = idSet(tags...)
+	r.L2Mode = types.StringValue(fields.L2_mode.Value)
+	r.UntaggedVlanId = types.StringValue(fields.Untagged_vlan.Node.Id)
+	r.TaggedVlans = idSet(taggedVlans...)
+	return diags
+}
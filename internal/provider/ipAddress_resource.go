@@ -0,0 +1,296 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ipAddressResource{}
+	_ resource.ResourceWithConfigure   = &ipAddressResource{}
+	_ resource.ResourceWithImportState = &ipAddressResource{}
+)
+
+// NewIPAddressResource is a helper function to simplify the provider implementation.
+func NewIPAddressResource() resource.Resource {
+	return &ipAddressResource{}
+}
+
+// ipAddressResource is the resource implementation.
+type ipAddressResource struct {
+	client      *graphql.Client
+	Id          types.String `tfsdk:"id"`
+	Address     types.String `tfsdk:"address"`
+	Description types.String `tfsdk:"description"`
+	InterfaceId types.String `tfsdk:"interface_id"`
+	NamespaceId types.String `tfsdk:"namespace_id"`
+	IpPrefixId  types.String `tfsdk:"ip_prefix_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *ipAddressResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_address"
+}
+
+// Schema defines the schema for the resource.
+func (r *ipAddressResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraIPAddress`. Import with the node ID or `<namespace>/<address>`, e.g. `default/10.0.0.1/24`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "IP address with prefix length, e.g. `10.0.0.1/24`",
+				Required:            true,
+				Validators: []validator.String{
+					cidrValidator{},
+				},
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"interface_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the layer 3 interface the address is assigned to, removing it detaches the address",
+				Optional:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the IPAM namespace, defaults to the default namespace",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_prefix_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the prefix the address belongs to",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ipAddressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ipAddressResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating IPAddress ", plan.Address))
+
+	response, err := infrahub_sdk.IPAddressCreate(
+		ctx,
+		*r.client,
+		plan.Address.ValueString(),
+		plan.Description.ValueString(),
+		plan.InterfaceId.ValueString(),
+		plan.NamespaceId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create ip address in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraIPAddressCreate.Object.IPAddressFields)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ipAddressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading IPAddress...")
+	var state ipAddressResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.IPAddress(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read ip address from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The address was deleted outside of Terraform
+	if len(response.InfraIPAddress.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.fill(response.InfraIPAddress.Edges[0].Node.IPAddressFields)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ipAddressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan ipAddressResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state ipAddressResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating IPAddress %s", state.Address.ValueString()))
+
+	response, err := infrahub_sdk.IPAddressUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Address.ValueString(),
+		plan.Description.ValueString(),
+		// An empty ID is sent as null, detaching the address from its interface
+		infrahub_sdk.RelatedNode{Id: plan.InterfaceId.ValueString()},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update ip address in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraIPAddressUpdate.Object.IPAddressFields)
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ipAddressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state ipAddressResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.IPAddressDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IPAddress",
+			"Could not delete ip address, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports an ip address by its node ID or by <namespace>/<address>.
+func (r *ipAddressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+
+	if namespace, address, ok := splitIPImportId(req.ID); ok {
+		response, err := infrahub_sdk.IPAddressLookup(ctx, *r.client, address, namespace)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read ip address from Infrahub",
+				err.Error(),
+			)
+			return
+		}
+
+		if len(response.InfraIPAddress.Edges) != 1 {
+			resp.Diagnostics.AddError(
+				"Didn't receive a single ip address, query didn't return exactly 1 ip address",
+				fmt.Sprintf("Expected exactly 1 ip address %s in namespace %s, got a different count.", address, namespace),
+			)
+			return
+		}
+
+		id = response.InfraIPAddress.Edges[0].Node.Id
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ipAddressResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *ipAddressResource) fill(fields infrahub_sdk.IPAddressFields) {
+	r.Id = types.StringValue(fields.Id)
+	r.Address = types.StringValue(fields.Address.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.InterfaceId = types.StringNull()
+	if fields.Interface.Node.Id != "" {
+		r.InterfaceId = types.StringValue(fields.Interface.Node.Id)
+	}
+	r.NamespaceId = types.StringValue(nodeId(fields.Ip_namespace.Node))
+	r.IpPrefixId = types.StringValue(nodeId(fields.Ip_prefix.Node))
+}
@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &ipPrefixAllocationResource{}
+	_ resource.ResourceWithConfigure = &ipPrefixAllocationResource{}
+)
+
+// NewIPPrefixAllocationResource is a helper function to simplify the provider implementation.
+func NewIPPrefixAllocationResource() resource.Resource {
+	return &ipPrefixAllocationResource{}
+}
+
+// ipPrefixAllocationResource allocates a prefix out of a CoreIPPrefixPool.
+type ipPrefixAllocationResource struct {
+	client       *graphql.Client
+	Id           types.String `tfsdk:"id"`
+	PoolId       types.String `tfsdk:"pool_id"`
+	Identifier   types.String `tfsdk:"identifier"`
+	Prefix       types.String `tfsdk:"prefix"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	MemberType   types.String `tfsdk:"member_type"`
+	PrefixType   types.String `tfsdk:"prefix_type"`
+}
+
+// Metadata returns the resource type name.
+func (r *ipPrefixAllocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_prefix_allocation"
+}
+
+// Schema defines the schema for the resource.
+func (r *ipPrefixAllocationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Allocates a prefix from a `CoreIPPrefixPool`. Allocations are keyed by `identifier`, " +
+			"so applying again with the same identifier returns the prefix that was allocated before.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the allocated prefix",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the CoreIPPrefixPool to allocate from",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identifier": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the allocation within the pool",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Allocated prefix",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: "Length of the prefix to allocate, defaults to the pool's default prefix length",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 128),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"member_type": schema.StringAttribute{
+				MarkdownDescription: "Member type of the allocated prefix, either `prefix` or `address`",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("prefix", "address"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prefix_type": schema.StringAttribute{
+				MarkdownDescription: "Kind of the prefix to allocate, defaults to the pool's default prefix type",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Create allocates a prefix from the pool and sets the initial Terraform state.
+func (r *ipPrefixAllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ipPrefixAllocationResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Allocating prefix %s from pool %s", plan.Identifier.ValueString(), plan.PoolId.ValueString()))
+
+	response, err := infrahub_sdk.IPPrefixAllocate(
+		ctx,
+		*r.client,
+		plan.PoolId.ValueString(),
+		plan.Identifier.ValueString(),
+		int(plan.PrefixLength.ValueInt64()),
+		plan.MemberType.ValueString(),
+		plan.PrefixType.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to allocate prefix in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	if !response.IPPrefixPoolGetResource.Ok || response.IPPrefixPoolGetResource.Node.Id == "" {
+		resp.Diagnostics.AddError(
+			"Unable to allocate prefix in Infrahub",
+			fmt.Sprintf("Pool %s didn't return a prefix for identifier %s, it may be exhausted.", plan.PoolId.ValueString(), plan.Identifier.ValueString()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(response.IPPrefixPoolGetResource.Node.Id)
+
+	prefix, err := infrahub_sdk.IPPrefixAllocation(ctx, *r.client, plan.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read allocated prefix from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	if len(prefix.InfraPrefix.Edges) != 1 {
+		resp.Diagnostics.AddError(
+			"Didn't receive a single prefix, query didn't return exactly 1 prefix",
+			"Expected exactly 1 prefix in response, got a different count.",
+		)
+		return
+	}
+
+	plan.Prefix = types.StringValue(prefix.InfraPrefix.Edges[0].Node.Prefix.Value)
+	plan.MemberType = types.StringValue(prefix.InfraPrefix.Edges[0].Node.Member_type.Value)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ipPrefixAllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading IPPrefixAllocation...")
+	var state ipPrefixAllocationResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.IPPrefixAllocation(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read allocated prefix from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The prefix was released outside of Terraform, allocate again on the next apply
+	if len(response.InfraPrefix.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Prefix = types.StringValue(response.InfraPrefix.Edges[0].Node.Prefix.Value)
+	state.MemberType = types.StringValue(response.InfraPrefix.Edges[0].Node.Member_type.Value)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called with changes since every argument forces a new allocation.
+func (r *ipPrefixAllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ipPrefixAllocationResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete releases the allocated prefix and removes the Terraform state on success.
+func (r *ipPrefixAllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state ipPrefixAllocationResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.IPPrefixAllocationDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting IPPrefixAllocation",
+			"Could not delete allocated prefix, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ipPrefixAllocationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
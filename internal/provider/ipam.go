@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Namespace used when a prefix or address is looked up without one.
+const defaultIPNamespace = "default"
+
+// resolvePrefixId returns prefixId if set, otherwise looks up the ID of prefix
+// within the IPAM namespace.
+func resolvePrefixId(ctx context.Context, client graphql.Client, prefixId, prefix, namespace string) (string, error) {
+	if prefixId != "" {
+		return prefixId, nil
+	}
+
+	if namespace == "" {
+		namespace = defaultIPNamespace
+	}
+
+	response, err := infrahub_sdk.PrefixLookup(ctx, client, prefix, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.InfraPrefix.Edges) != 1 {
+		return "", fmt.Errorf("expected exactly 1 prefix %s in namespace %s, got %d", prefix, namespace, len(response.InfraPrefix.Edges))
+	}
+
+	return response.InfraPrefix.Edges[0].Node.Id, nil
+}
+
+// nextAvailable resolves the parent prefix of the next available data
+// sources and returns it with the free resource returned by next. what names
+// the resource in the errors, e.g. "IP address".
+func nextAvailable(ctx context.Context, client graphql.Client, prefixId, prefix, namespace string, what string, next func(prefixId string) (string, error)) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	prefixId, err := resolvePrefixId(ctx, client, prefixId, prefix, namespace)
+	if err != nil {
+		diags.AddError(
+			"Unable to read prefix from Infrahub",
+			err.Error(),
+		)
+		return "", "", diags
+	}
+
+	value, err := next(prefixId)
+	if err != nil {
+		diags.AddError(
+			"Unable to read next available "+what+" from Infrahub",
+			err.Error(),
+		)
+		return "", "", diags
+	}
+
+	// The server answers with an empty result when the prefix is exhausted
+	if value == "" {
+		diags.AddError(
+			"No "+what+" available in Infrahub",
+			fmt.Sprintf("Prefix %s has no free %s left.", prefixId, what),
+		)
+	}
+	return prefixId, value, diags
+}
+
+// splitIPImportId splits an import ID of the form <namespace>/<cidr> or <cidr>
+// into the namespace and the address or prefix. ok is false when the ID is not
+// in CIDR notation, in which case it is taken as the ID of the node.
+func splitIPImportId(id string) (namespace, cidr string, ok bool) {
+	if _, err := netip.ParsePrefix(id); err == nil {
+		return defaultIPNamespace, id, true
+	}
+
+	namespace, cidr, found := strings.Cut(id, "/")
+	if !found || namespace == "" {
+		return "", "", false
+	}
+
+	if _, err := netip.ParsePrefix(cidr); err != nil {
+		return "", "", false
+	}
+
+	return namespace, cidr, true
+}
+
+// nodeId returns the ID of a related node whose peer is a generic, genqlient
+// leaves the interface nil when the relationship is empty.
+func nodeId(node interface{ GetId() string }) string {
+	if node == nil {
+		return ""
+	}
+	return node.GetId()
+}
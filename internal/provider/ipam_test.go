@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "testing"
+
+func TestSplitIPImportId(t *testing.T) {
+	tests := []struct {
+		id        string
+		namespace string
+		cidr      string
+		ok        bool
+	}{
+		{id: "10.0.0.1/24", namespace: "default", cidr: "10.0.0.1/24", ok: true},
+		{id: "2001:db8::1/64", namespace: "default", cidr: "2001:db8::1/64", ok: true},
+		{id: "customer-a/10.0.0.1/24", namespace: "customer-a", cidr: "10.0.0.1/24", ok: true},
+		{id: "customer-a/2001:db8::1/64", namespace: "customer-a", cidr: "2001:db8::1/64", ok: true},
+		{id: "10.0.0.1"},
+		{id: "/10.0.0.1/24"},
+		{id: "customer-a/10.0.0.1"},
+		{id: "customer-a/"},
+		{id: ""},
+	}
+	for _, test := range tests {
+		namespace, cidr, ok := splitIPImportId(test.id)
+		if namespace != test.namespace || cidr != test.cidr || ok != test.ok {
+			t.Errorf("splitIPImportId(%q) = %q, %q, %t, want %q, %q, %t",
+				test.id, namespace, cidr, ok, test.namespace, test.cidr, test.ok)
+		}
+	}
+}
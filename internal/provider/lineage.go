@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// lineage is the source and owner that generated resources record on every
+// attribute and relationship they write, taken from the provider
+// `source_account_id` and `owner_id`.
+type lineage struct {
+	source string
+	owner  string
+}
+
+// providerLineage returns the lineage configured on the provider from the
+// provider data handed to Configure.
+func providerLineage(providerData any) lineage {
+	if client, ok := providerData.(*infrahubClient); ok {
+		return lineage{source: client.sourceAccountId, owner: client.ownerId}
+	}
+	return lineage{}
+}
+
+// set fills the metadata of an attribute or relationship input.
+func (l lineage) set(source *string, owner *string, protected *bool, protect types.Bool) {
+	*source = l.source
+	*owner = l.owner
+	*protected = protect.ValueBool()
+}
+
+// lineageAttributes returns the schema of the attributes controlling and
+// exposing the lineage of a resource.
+func lineageAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"protect_attributes": schema.BoolAttribute{
+			MarkdownDescription: "Mark the attributes and relationships written by Terraform as protected, so they cannot be edited in the UI",
+			Optional:            true,
+		},
+		"attribute_owners": schema.MapAttribute{
+			MarkdownDescription: "ID of the current owner of each attribute that has one",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"attribute_sources": schema.MapAttribute{
+			MarkdownDescription: "ID of the current source of each attribute that has one",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+// lineageMap converts node IDs keyed by attribute name into a map, skipping
+// attributes without an owner or source.
+func lineageMap(ids map[string]string) types.Map {
+	elements := map[string]attr.Value{}
+	for name, id := range ids {
+		if id != "" {
+			elements[name] = types.StringValue(id)
+		}
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &locationTreeDataSource{}
+	_ datasource.DataSourceWithConfigure = &locationTreeDataSource{}
+)
+
+// locationTreePageSize is the number of ancestors and descendants read per
+// query, the server caps the size of a page.
+const locationTreePageSize = 100
+
+// NewLocationTreeDataSource is a helper function to simplify the provider implementation.
+func NewLocationTreeDataSource() datasource.DataSource {
+	return &locationTreeDataSource{}
+}
+
+type locationTreeDataSource struct {
+	client      *graphql.Client
+	Id          types.String            `tfsdk:"id"`
+	Ancestors   []locationTreeNodeModel `tfsdk:"ancestors"`
+	Descendants []locationTreeNodeModel `tfsdk:"descendants"`
+}
+
+type locationTreeNodeModel struct {
+	Id        types.String `tfsdk:"id"`
+	Kind      types.String `tfsdk:"kind"`
+	Name      types.String `tfsdk:"name"`
+	Shortname types.String `tfsdk:"shortname"`
+	ParentId  types.String `tfsdk:"parent_id"`
+}
+
+func (d *locationTreeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_location_tree"
+}
+
+func (d *locationTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nodes := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the location, e.g. `LocationMetro`",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"shortname": schema.StringAttribute{
+				Computed: true,
+			},
+			"parent_id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the ancestors and descendants of a location, e.g. to walk from a rack up to its metro.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the location",
+				Required:            true,
+			},
+			"ancestors": schema.ListNestedAttribute{
+				MarkdownDescription: "Locations above this one in the hierarchy, ordered from the parent up to the root",
+				Computed:            true,
+				NestedObject:        nodes,
+			},
+			"descendants": schema.ListNestedAttribute{
+				MarkdownDescription: "Locations below this one in the hierarchy",
+				Computed:            true,
+				NestedObject:        nodes,
+			},
+		},
+	}
+}
+
+func (d *locationTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading location tree data...")
+	var config locationTreeDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := locationTreeDataSource{
+		Id:          config.Id,
+		Ancestors:   []locationTreeNodeModel{},
+		Descendants: []locationTreeNodeModel{},
+	}
+	parentId := ""
+	for offset := 0; ; offset += locationTreePageSize {
+		response, err := infrahub_sdk.LocationTree(ctx, *d.client, config.Id.ValueString(), offset, locationTreePageSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read location tree from Infrahub",
+				err.Error(),
+			)
+			return
+		}
+
+		if len(response.LocationGeneric.Edges) == 0 {
+			resp.Diagnostics.AddError(
+				"Location not found",
+				fmt.Sprintf("No location with ID %s exists in Infrahub", config.Id.ValueString()),
+			)
+			return
+		}
+
+		node := response.LocationGeneric.Edges[0].Node
+		parentId = nodeId(node.GetParent().Node)
+		ancestors, descendants := node.GetAncestors(), node.GetDescendants()
+		for _, edge := range ancestors.Edges {
+			state.Ancestors = append(state.Ancestors, newLocationTreeNodeModel(edge.Node))
+		}
+		for _, edge := range descendants.Edges {
+			state.Descendants = append(state.Descendants, newLocationTreeNodeModel(edge.Node))
+		}
+
+		// Stop on the last page of both, also when locations are removed while reading
+		if (len(ancestors.Edges) == 0 || len(state.Ancestors) >= ancestors.Count) &&
+			(len(descendants.Edges) == 0 || len(state.Descendants) >= descendants.Count) {
+			break
+		}
+	}
+	state.Ancestors = sortLocationAncestors(parentId, state.Ancestors)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *locationTreeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
+
+func newLocationTreeNodeModel(node infrahub_sdk.LocationTreeNode) locationTreeNodeModel {
+	return locationTreeNodeModel{
+		Id:        types.StringValue(node.GetId()),
+		Kind:      types.StringValue(node.GetTypename()),
+		Name:      types.StringValue(node.GetName().Value),
+		Shortname: types.StringValue(node.GetShortname().Value),
+		ParentId:  types.StringValue(nodeId(node.GetParent().Node)),
+	}
+}
+
+// sortLocationAncestors orders the ancestors of a location from its parent up
+// to the root by following the parent of each. Ancestors outside of that chain
+// are kept at the end in the order returned by Infrahub.
+func sortLocationAncestors(parentId string, ancestors []locationTreeNodeModel) []locationTreeNodeModel {
+	byId := map[string]locationTreeNodeModel{}
+	for _, ancestor := range ancestors {
+		byId[ancestor.Id.ValueString()] = ancestor
+	}
+
+	sorted := []locationTreeNodeModel{}
+	for id := parentId; id != ""; {
+		ancestor, ok := byId[id]
+		if !ok {
+			break
+		}
+		// Removing the ancestor also stops on a loop of parents
+		delete(byId, id)
+		sorted = append(sorted, ancestor)
+		id = ancestor.ParentId.ValueString()
+	}
+	for _, ancestor := range ancestors {
+		if _, ok := byId[ancestor.Id.ValueString()]; ok {
+			sorted = append(sorted, ancestor)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSortLocationAncestors(t *testing.T) {
+	location := func(id string, parentId string) locationTreeNodeModel {
+		return locationTreeNodeModel{Id: types.StringValue(id), ParentId: types.StringValue(parentId)}
+	}
+	continent := location("continent", "")
+	country := location("country", "continent")
+	metro := location("metro", "country")
+	building := location("building", "metro")
+
+	tests := []struct {
+		name      string
+		parentId  string
+		ancestors []locationTreeNodeModel
+		want      []string
+	}{
+		{
+			name:      "unordered",
+			parentId:  "building",
+			ancestors: []locationTreeNodeModel{country, building, continent, metro},
+			want:      []string{"building", "metro", "country", "continent"},
+		},
+		{
+			name:      "outside of the chain",
+			parentId:  "metro",
+			ancestors: []locationTreeNodeModel{location("other", "continent"), continent, metro, country},
+			want:      []string{"metro", "country", "continent", "other"},
+		},
+		{
+			name:      "loop of parents",
+			parentId:  "a",
+			ancestors: []locationTreeNodeModel{location("b", "a"), location("a", "b")},
+			want:      []string{"a", "b"},
+		},
+		{
+			name:      "root location",
+			ancestors: []locationTreeNodeModel{},
+			want:      []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := []string{}
+			for _, ancestor := range sortLocationAncestors(test.parentId, test.ancestors) {
+				got = append(got, ancestor.Id.ValueString())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("sortLocationAncestors() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
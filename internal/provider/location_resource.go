@@ -0,0 +1,512 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &locationResource{}
+	_ resource.ResourceWithConfigure   = &locationResource{}
+	_ resource.ResourceWithImportState = &locationResource{}
+	_ resource.ResourceWithModifyPlan  = &locationResource{}
+)
+
+// locationKind describes one level of the location hierarchy. All levels
+// share the LocationGeneric attributes, but Infrahub generates separate
+// mutations for every kind.
+type locationKind struct {
+	name     string
+	kind     string
+	facility bool
+	create   func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error)
+	update   func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error)
+	delete   func(ctx context.Context, client graphql.Client, id string) error
+}
+
+var (
+	locationContinent = locationKind{
+		name: "continent",
+		kind: "LocationContinent",
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationContinentCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationContinentCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationContinentUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationContinentUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationContinentDelete(ctx, client, id)
+			return err
+		},
+	}
+	locationCountry = locationKind{
+		name: "country",
+		kind: "LocationCountry",
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationCountryCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationCountryCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationCountryUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationCountryUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationCountryDelete(ctx, client, id)
+			return err
+		},
+	}
+	locationMetro = locationKind{
+		name: "metro",
+		kind: "LocationMetro",
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationMetroCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationMetroCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationMetroUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationMetroUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationMetroDelete(ctx, client, id)
+			return err
+		},
+	}
+	locationBuilding = locationKind{
+		name:     "building",
+		kind:     "LocationBuilding",
+		facility: true,
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationBuildingCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), facilityId, m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationBuildingCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationBuildingUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), facilityId, infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationBuildingUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationBuildingDelete(ctx, client, id)
+			return err
+		},
+	}
+	locationFloor = locationKind{
+		name: "floor",
+		kind: "LocationFloor",
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationFloorCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationFloorCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, _ string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationFloorUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationFloorUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationFloorDelete(ctx, client, id)
+			return err
+		},
+	}
+	locationSuite = locationKind{
+		name:     "suite",
+		kind:     "LocationSuite",
+		facility: true,
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationSuiteCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), facilityId, m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationSuiteCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationSuiteUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), facilityId, infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationSuiteUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationSuiteDelete(ctx, client, id)
+			return err
+		},
+	}
+	locationRack = locationKind{
+		name:     "rack",
+		kind:     "LocationRack",
+		facility: true,
+		create: func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationRackCreate(ctx, client, m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), facilityId, m.ParentId.ValueString(), tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationRackCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, m *locationModel, facilityId string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.LocationRackUpdate(ctx, client, m.Id.ValueString(), m.Name.ValueString(), m.Shortname.ValueString(), m.Description.ValueString(), m.Timezone.ValueString(), facilityId, infrahub_sdk.RelatedNode{Id: m.ParentId.ValueString()}, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.LocationRackUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.LocationRackDelete(ctx, client, id)
+			return err
+		},
+	}
+)
+
+// NewLocationContinentResource is a helper function to simplify the provider implementation.
+func NewLocationContinentResource() resource.Resource {
+	return &locationResource{kind: locationContinent}
+}
+
+// NewLocationCountryResource is a helper function to simplify the provider implementation.
+func NewLocationCountryResource() resource.Resource {
+	return &locationResource{kind: locationCountry}
+}
+
+// NewLocationMetroResource is a helper function to simplify the provider implementation.
+func NewLocationMetroResource() resource.Resource {
+	return &locationResource{kind: locationMetro}
+}
+
+// NewLocationBuildingResource is a helper function to simplify the provider implementation.
+func NewLocationBuildingResource() resource.Resource {
+	return &locationResource{kind: locationBuilding}
+}
+
+// NewLocationFloorResource is a helper function to simplify the provider implementation.
+func NewLocationFloorResource() resource.Resource {
+	return &locationResource{kind: locationFloor}
+}
+
+// NewLocationSuiteResource is a helper function to simplify the provider implementation.
+func NewLocationSuiteResource() resource.Resource {
+	return &locationResource{kind: locationSuite}
+}
+
+// NewLocationRackResource is a helper function to simplify the provider implementation.
+func NewLocationRackResource() resource.Resource {
+	return &locationResource{kind: locationRack}
+}
+
+// locationResource is the resource implementation shared by all location kinds.
+type locationResource struct {
+	client      *graphql.Client
+	kind        locationKind
+	defaultTags []string
+}
+
+// locationModel holds the attributes every location kind has.
+type locationModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Shortname   types.String `tfsdk:"shortname"`
+	Description types.String `tfsdk:"description"`
+	Timezone    types.String `tfsdk:"timezone"`
+	ParentId    types.String `tfsdk:"parent_id"`
+	Tags        types.Set    `tfsdk:"tags"`
+	TagsAll     types.Set    `tfsdk:"tags_all"`
+}
+
+// facilityLocationModel is used by buildings, suites and racks, which also
+// carry a facility_id.
+type facilityLocationModel struct {
+	locationModel
+	FacilityId types.String `tfsdk:"facility_id"`
+}
+
+// model returns an empty model matching the schema of the location kind,
+// together with pointers to its common and facility_id fields.
+func (r *locationResource) model() (any, *locationModel, *types.String) {
+	if r.kind.facility {
+		m := &facilityLocationModel{}
+		return m, &m.locationModel, &m.FacilityId
+	}
+	m := &locationModel{}
+	return m, m, nil
+}
+
+// Metadata returns the resource type name.
+func (r *locationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.name
+}
+
+// Schema defines the schema for the resource.
+func (r *locationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required: true,
+		},
+		"shortname": schema.StringAttribute{
+			Required: true,
+		},
+		"description": optionalComputedString(""),
+		"timezone":    optionalComputedString("Timezone of the location, e.g. `Europe/Paris`"),
+		"parent_id":   optionalComputedString("ID of the parent location"),
+		"tags":        tagsAttribute(),
+		"tags_all":    tagsAllAttribute(),
+	}
+	if r.kind.facility {
+		attributes["facility_id"] = optionalComputedString("Identifier of the facility")
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a `%s`.", r.kind.kind),
+		Attributes:          attributes,
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *locationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	plan, location, facilityId := r.model()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating ", r.kind.kind, " ", location.Name))
+
+	id, err := r.kind.create(ctx, *r.client, location, r.facilityId(facilityId), relatedNodes(mergeTags(location.Tags, r.defaultTags)))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create %s in Infrahub", r.kind.name),
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, id, location, facilityId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *locationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, fmt.Sprintf("Reading %s...", r.kind.kind))
+	state, location, facilityId := r.model()
+
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, location.Id.ValueString(), location, facilityId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The location was deleted outside of Terraform
+	if location.Id.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *locationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	plan, location, facilityId := r.model()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating %s %s", r.kind.kind, location.Name.ValueString()))
+
+	id, err := r.kind.update(ctx, *r.client, location, r.facilityId(facilityId), relatedNodes(mergeTags(location.Tags, r.defaultTags)))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to update %s in Infrahub", r.kind.name),
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, id, location, facilityId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *locationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	state, location, _ := r.model()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.kind.delete(ctx, *r.client, location.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting "+r.kind.kind,
+			fmt.Sprintf("Could not delete %s, unexpected error: %s", r.kind.name, err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports a location by its node ID.
+func (r *locationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *locationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.defaultTags = providerDefaultTags(req.ProviderData)
+}
+
+// ModifyPlan adds the provider default tags to tags_all.
+func (r *locationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, req, resp, r.defaultTags)...)
+}
+
+// facilityId returns the planned facility_id, or "" for kinds without one.
+func (r *locationResource) facilityId(facilityId *types.String) string {
+	if facilityId == nil {
+		return ""
+	}
+	return facilityId.ValueString()
+}
+
+// read fetches the location from Infrahub and copies it into the model. The
+// ID is set to null when the location no longer exists or has another kind.
+func (r *locationResource) read(ctx context.Context, id string, location *locationModel, facilityId *types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	response, err := infrahub_sdk.Location(ctx, *r.client, id)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to read %s from Infrahub", r.kind.name),
+			err.Error(),
+		)
+		return diags
+	}
+
+	if len(response.LocationGeneric.Edges) == 0 || response.LocationGeneric.Edges[0].Node.GetTypename() != r.kind.kind {
+		location.Id = types.StringNull()
+		return diags
+	}
+
+	node := response.LocationGeneric.Edges[0].Node
+	tags := []string{}
+	for _, edge := range node.GetTags().Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+
+	location.Id = types.StringValue(node.GetId())
+	location.Name = types.StringValue(node.GetName().Value)
+	location.Shortname = types.StringValue(node.GetShortname().Value)
+	location.Description = types.StringValue(node.GetDescription().Value)
+	location.Timezone = types.StringValue(node.GetTimezone().Value)
+	location.ParentId = types.StringValue(nodeId(node.GetParent().Node))
+	location.Tags = resourceTags(tags, location.Tags, r.defaultTags)
+	location.TagsAll = idSet(tags...)
+
+	if facility, ok := node.(interface {
+		GetFacility_id() infrahub_sdk.LocationFieldsFacility_idTextAttribute
+	}); ok && facilityId != nil {
+		*facilityId = types.StringValue(facility.GetFacility_id().Value)
+	}
+	return diags
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &nextAvailableIPDataSource{}
+	_ datasource.DataSourceWithConfigure        = &nextAvailableIPDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &nextAvailableIPDataSource{}
+)
+
+// NewNextAvailableIPDataSource is a helper function to simplify the provider implementation.
+func NewNextAvailableIPDataSource() datasource.DataSource {
+	return &nextAvailableIPDataSource{}
+}
+
+type nextAvailableIPDataSource struct {
+	client       *graphql.Client
+	PrefixId     types.String `tfsdk:"prefix_id"`
+	Prefix       types.String `tfsdk:"prefix"`
+	Namespace    types.String `tfsdk:"namespace"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	NextAddress  types.String `tfsdk:"next_address"`
+}
+
+func (d *nextAvailableIPDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_next_available_ip"
+}
+
+func (d *nextAvailableIPDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Next free IP address within a prefix. The address is only previewed, not allocated.",
+		Attributes: map[string]schema.Attribute{
+			"prefix_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the parent prefix, conflicts with `prefix`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Parent prefix, e.g. `10.0.0.0/24`, conflicts with `prefix_id`",
+				Optional:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Name of the IPAM namespace of `prefix`, defaults to `default`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("prefix_id")),
+				},
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: "Prefix length of the returned address, defaults to the length of the parent prefix",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 128),
+				},
+			},
+			"next_address": schema.StringAttribute{
+				MarkdownDescription: "Next available IP address",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *nextAvailableIPDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("prefix_id"),
+			path.MatchRoot("prefix"),
+		),
+	}
+}
+
+func (d *nextAvailableIPDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading next available IP address...")
+	var config nextAvailableIPDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefixId, next, diags := nextAvailable(ctx, *d.client, config.PrefixId.ValueString(), config.Prefix.ValueString(), config.Namespace.ValueString(), "IP address",
+		func(prefixId string) (string, error) {
+			response, err := infrahub_sdk.NextAvailableIP(ctx, *d.client, prefixId, int(config.PrefixLength.ValueInt64()))
+			if err != nil {
+				return "", err
+			}
+			return response.IPAddressGetNextAvailable.Address, nil
+		},
+	)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := nextAvailableIPDataSource{
+		PrefixId:     types.StringValue(prefixId),
+		Prefix:       config.Prefix,
+		Namespace:    config.Namespace,
+		PrefixLength: config.PrefixLength,
+		NextAddress:  types.StringValue(next),
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nextAvailableIPDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &nextAvailablePrefixDataSource{}
+	_ datasource.DataSourceWithConfigure        = &nextAvailablePrefixDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &nextAvailablePrefixDataSource{}
+)
+
+// NewNextAvailablePrefixDataSource is a helper function to simplify the provider implementation.
+func NewNextAvailablePrefixDataSource() datasource.DataSource {
+	return &nextAvailablePrefixDataSource{}
+}
+
+type nextAvailablePrefixDataSource struct {
+	client       *graphql.Client
+	PrefixId     types.String `tfsdk:"prefix_id"`
+	Prefix       types.String `tfsdk:"prefix"`
+	Namespace    types.String `tfsdk:"namespace"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	NextPrefix   types.String `tfsdk:"next_prefix"`
+}
+
+func (d *nextAvailablePrefixDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_next_available_prefix"
+}
+
+func (d *nextAvailablePrefixDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Next free prefix within a parent prefix. The prefix is only previewed, not allocated.",
+		Attributes: map[string]schema.Attribute{
+			"prefix_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the parent prefix, conflicts with `prefix`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Parent prefix, e.g. `10.0.0.0/24`, conflicts with `prefix_id`",
+				Optional:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Name of the IPAM namespace of `prefix`, defaults to `default`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("prefix_id")),
+				},
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: "Length of the returned prefix",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 128),
+				},
+			},
+			"next_prefix": schema.StringAttribute{
+				MarkdownDescription: "Next available prefix",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *nextAvailablePrefixDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("prefix_id"),
+			path.MatchRoot("prefix"),
+		),
+	}
+}
+
+func (d *nextAvailablePrefixDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading next available prefix...")
+	var config nextAvailablePrefixDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefixId, next, diags := nextAvailable(ctx, *d.client, config.PrefixId.ValueString(), config.Prefix.ValueString(), config.Namespace.ValueString(), "prefix",
+		func(prefixId string) (string, error) {
+			response, err := infrahub_sdk.NextAvailablePrefix(ctx, *d.client, prefixId, int(config.PrefixLength.ValueInt64()))
+			if err != nil {
+				return "", err
+			}
+			return response.IPPrefixGetNextAvailable.Prefix, nil
+		},
+	)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := nextAvailablePrefixDataSource{
+		PrefixId:     types.StringValue(prefixId),
+		Prefix:       config.Prefix,
+		Namespace:    config.Namespace,
+		PrefixLength: config.PrefixLength,
+		NextPrefix:   types.StringValue(next),
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nextAvailablePrefixDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
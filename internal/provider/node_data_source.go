@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &nodeDataSource{}
+	_ datasource.DataSourceWithConfigure        = &nodeDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &nodeDataSource{}
+)
+
+// NewNodeDataSource is a helper function to simplify the provider implementation.
+func NewNodeDataSource() datasource.DataSource {
+	return &nodeDataSource{}
+}
+
+type nodeDataSource struct {
+	client        *graphql.Client
+	schema        schemaAPI
+	Kind          types.String  `tfsdk:"kind"`
+	Id            types.String  `tfsdk:"id"`
+	Hfid          types.List    `tfsdk:"hfid"`
+	Filters       types.Map     `tfsdk:"filters"`
+	DisplayLabel  types.String  `tfsdk:"display_label"`
+	Attributes    types.Dynamic `tfsdk:"attributes"`
+	Relationships types.Map     `tfsdk:"relationships"`
+}
+
+func (d *nodeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node"
+}
+
+func (d *nodeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a node of any kind by ID, human friendly ID or filters. The query is built from the " +
+			"schema of the server, so kinds added by schema extensions can be read without a dedicated data source. " +
+			"Fails unless exactly one node matches.",
+		Attributes: map[string]schema.Attribute{
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the node, e.g. `SecurityZone`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(kindPattern, "must be a kind, e.g. `SecurityZone`"),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the node, conflicts with `hfid` and `filters`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"hfid": schema.ListAttribute{
+				MarkdownDescription: "Human friendly ID of the node, e.g. `[\"atl1-edge1\"]`, conflicts with `id` and `filters`",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"filters": schema.MapAttribute{
+				MarkdownDescription: nodeFiltersDescription + ", conflicts with `id` and `hfid`",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"display_label": schema.StringAttribute{
+				MarkdownDescription: "Label of the node in the UI",
+				Computed:            true,
+			},
+			"attributes": schema.DynamicAttribute{
+				MarkdownDescription: "Values of the attributes of the node by attribute name",
+				Computed:            true,
+			},
+			"relationships": schema.MapAttribute{
+				MarkdownDescription: "IDs of the peers of the node by relationship name",
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (d *nodeDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("hfid"),
+			path.MatchRoot("filters"),
+		),
+	}
+}
+
+func (d *nodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading node data...")
+	var config nodeDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := config.Kind.ValueString()
+	schemaNode, err := d.schema.node(ctx, kind)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read schema from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	if schemaNode == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kind"),
+			"Unknown kind",
+			fmt.Sprintf("%s is not a kind of the schema of the server", kind),
+		)
+		return
+	}
+
+	arguments := []string{}
+	switch {
+	case !config.Id.IsNull():
+		arguments = append(arguments, "ids: "+graphqlLiteral([]string{config.Id.ValueString()}))
+	case !config.Hfid.IsNull():
+		hfid := []string{}
+		resp.Diagnostics.Append(config.Hfid.ElementsAs(ctx, &hfid, false)...)
+		arguments = append(arguments, "hfid: "+graphqlLiteral(hfid))
+	default:
+		arguments = nodeFilters(schemaNode, config.Filters, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Two nodes are enough to tell that the lookup is ambiguous
+	count, nodes, err := queryNodes(ctx, *d.client, schemaNode, append(arguments, "limit: 2"))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read "+kind+" from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	if count != 1 {
+		resp.Diagnostics.AddError(
+			"Unable to find "+kind+" in Infrahub",
+			fmt.Sprintf("Expected exactly one %s, got %d", kind, count),
+		)
+		return
+	}
+
+	node := nodeValues(schemaNode, nodes[0])
+	state := nodeDataSource{
+		Kind:         config.Kind,
+		Id:           types.StringValue(node.Id),
+		Filters:      config.Filters,
+		DisplayLabel: types.StringValue(node.DisplayLabel),
+		Attributes:   types.DynamicValue(terraformValue(node.Attributes)),
+	}
+	state.Hfid, diags = types.ListValueFrom(ctx, types.StringType, node.Hfid)
+	resp.Diagnostics.Append(diags...)
+	state.Relationships, diags = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, node.Relationships)
+	resp.Diagnostics.Append(diags...)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nodeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+	d.schema = providerSchemaAPI(req.ProviderData)
+}
+
+// nodeFiltersDescription documents the filters of the generic node data
+// sources.
+const nodeFiltersDescription = "Filters of the GraphQL query of the kind, e.g. `{ name__value = \"dmz\" }` or " +
+	"`{ role__values = \"leaf\", location__ids = \"...\" }`"
+
+// nodeFields are the values of a node read by queryNodes.
+type nodeFields struct {
+	Id            string
+	Hfid          []string
+	DisplayLabel  string
+	Attributes    map[string]any
+	Relationships map[string][]string
+}
+
+// nodeFilters returns the GraphQL arguments of the filters of a node data
+// source. The name of a filter starts with an attribute or a relationship
+// of the kind, so that the arguments only hold names of the schema.
+func nodeFilters(node *schemaNode, filters types.Map, diags *diag.Diagnostics) []string {
+	arguments := []string{}
+	elements := filters.Elements()
+	for _, name := range slices.Sorted(maps.Keys(elements)) {
+		field, _, _ := strings.Cut(name, "__")
+		if !namePattern.MatchString(name) || (field != "any" && name != "partial_match" && !node.hasAttribute(field) && node.relationship(field) == nil) {
+			diags.AddAttributeError(
+				path.Root("filters").AtMapKey(name),
+				"Unknown filter",
+				fmt.Sprintf("%s is not a filter of %s", name, node.Kind),
+			)
+			continue
+		}
+
+		value := elements[name].(types.String).ValueString()
+		switch {
+		case name == "partial_match" || strings.HasSuffix(name, "__isnull") || strings.HasSuffix(name, "__is_visible") || strings.HasSuffix(name, "__is_protected"):
+			// Boolean arguments
+			if value != "true" && value != "false" {
+				diags.AddAttributeError(
+					path.Root("filters").AtMapKey(name),
+					"Invalid filter",
+					fmt.Sprintf("%s expects true or false, got %q", name, value),
+				)
+				continue
+			}
+			arguments = append(arguments, name+": "+value)
+		default:
+			// Lists of one value, e.g. role__values, are coerced by the server
+			arguments = append(arguments, name+": "+graphqlLiteral(value))
+		}
+	}
+	return arguments
+}
+
+// graphqlLiteral returns a string or a list of strings as a GraphQL literal,
+// JSON strings being valid GraphQL strings.
+func graphqlLiteral(value any) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// queryNodes returns the total count and the nodes of a kind matching the
+// GraphQL arguments, with the values of all the attributes and the peer IDs
+// of all the relationships of the kind.
+func queryNodes(ctx context.Context, client graphql.Client, node *schemaNode, arguments []string) (int, []map[string]any, error) {
+	attributes := []string{}
+	for _, attribute := range node.Attributes {
+		attributes = append(attributes, attribute.Name)
+	}
+	relationships := []string{}
+	for _, relationship := range node.Relationships {
+		relationships = append(relationships, relationship.Name)
+	}
+
+	var response map[string]struct {
+		Count int `json:"count"`
+		Edges []struct {
+			Node map[string]any `json:"node"`
+		} `json:"edges"`
+	}
+	query := fmt.Sprintf("query NodeRead { %s(%s) { count edges { node { %s } } } }",
+		node.Kind, strings.Join(arguments, ", "), nodeSelection(node, attributes, relationships))
+	if err := graphqlRequest(ctx, client, "NodeRead", query, nil, &response); err != nil {
+		return 0, nil, err
+	}
+
+	nodes := []map[string]any{}
+	for _, edge := range response[node.Kind].Edges {
+		nodes = append(nodes, edge.Node)
+	}
+	return response[node.Kind].Count, nodes, nil
+}
+
+// nodeValues returns the values of a node returned by queryNodes.
+func nodeValues(schemaNode *schemaNode, node map[string]any) nodeFields {
+	fields := nodeFields{
+		Attributes:    map[string]any{},
+		Relationships: map[string][]string{},
+	}
+	fields.Id, _ = node["id"].(string)
+	fields.DisplayLabel, _ = node["display_label"].(string)
+	fields.Hfid = []string{}
+	if values, ok := node["hfid"].([]any); ok {
+		for _, value := range values {
+			fields.Hfid = append(fields.Hfid, fmt.Sprint(value))
+		}
+	}
+	for _, attribute := range schemaNode.Attributes {
+		fields.Attributes[attribute.Name] = attributeValue(node, attribute.Name)
+	}
+	for _, relationship := range schemaNode.Relationships {
+		fields.Relationships[relationship.Name] = relationshipIDs(node, relationship.Name)
+	}
+	return fields
+}
+
+// nodeObject returns the values of a node as a Terraform object, for the
+// nodes listed in a dynamic attribute.
+func nodeObject(fields nodeFields) attr.Value {
+	hfid := []any{}
+	for _, value := range fields.Hfid {
+		hfid = append(hfid, value)
+	}
+	relationships := map[string]any{}
+	for name, ids := range fields.Relationships {
+		peers := []any{}
+		for _, id := range ids {
+			peers = append(peers, id)
+		}
+		relationships[name] = peers
+	}
+	return terraformValue(map[string]any{
+		"id":            fields.Id,
+		"hfid":          hfid,
+		"display_label": fields.DisplayLabel,
+		"attributes":    fields.Attributes,
+		"relationships": relationships,
+	})
+}
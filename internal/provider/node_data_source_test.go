@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNodeFilters(t *testing.T) {
+	node := &schemaNode{
+		Kind: "SecurityZone",
+		Attributes: []schemaAttribute{
+			{Name: "name"},
+			{Name: "description"},
+		},
+		Relationships: []schemaRelationship{
+			{Name: "policies", Cardinality: "many"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		filters    map[string]string
+		want       []string
+		wantErrors int
+	}{
+		{
+			name:    "attributes sorted by name",
+			filters: map[string]string{"name__value": "dmz", "description__value": "Demilitarized zone"},
+			want:    []string{`description__value: "Demilitarized zone"`, `name__value: "dmz"`},
+		},
+		{
+			name:    "relationship",
+			filters: map[string]string{"policies__ids": "17fc2f6f"},
+			want:    []string{`policies__ids: "17fc2f6f"`},
+		},
+		{
+			name:    "booleans",
+			filters: map[string]string{"partial_match": "true", "name__isnull": "false"},
+			want:    []string{"name__isnull: false", "partial_match: true"},
+		},
+		{
+			name:    "quotes are escaped",
+			filters: map[string]string{"any__value": `dmz") { id } #`},
+			want:    []string{`any__value: "dmz\") { id } #"`},
+		},
+		{
+			name:       "unknown attribute",
+			filters:    map[string]string{"zone__value": "dmz"},
+			want:       []string{},
+			wantErrors: 1,
+		},
+		{
+			name:       "invalid name",
+			filters:    map[string]string{"name__value: \"x\") {": "dmz"},
+			want:       []string{},
+			wantErrors: 1,
+		},
+		{
+			name:       "invalid boolean",
+			filters:    map[string]string{"partial_match": "yes", "name__value": "dmz"},
+			want:       []string{`name__value: "dmz"`},
+			wantErrors: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			elements := map[string]attr.Value{}
+			for name, value := range test.filters {
+				elements[name] = types.StringValue(value)
+			}
+			var diags diag.Diagnostics
+			got := nodeFilters(node, types.MapValueMust(types.StringType, elements), &diags)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("nodeFilters() = %q, want %q", got, test.want)
+			}
+			if diags.ErrorsCount() != test.wantErrors {
+				t.Errorf("nodeFilters() errors = %v, want %d", diags, test.wantErrors)
+			}
+		})
+	}
+}
+
+func TestGraphqlLiteral(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{value: "dmz", want: `"dmz"`},
+		{value: `a "quoted" \ value`, want: `"a \"quoted\" \\ value"`},
+		{value: "line\nbreak", want: `"line\nbreak"`},
+		{value: 4094, want: "4094"},
+		{value: true, want: "true"},
+		{value: []string{"a", "b"}, want: `["a","b"]`},
+	}
+	for _, test := range tests {
+		if got := graphqlLiteral(test.value); got != test.want {
+			t.Errorf("graphqlLiteral(%#v) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
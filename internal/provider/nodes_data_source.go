@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &nodesDataSource{}
+	_ datasource.DataSourceWithConfigure = &nodesDataSource{}
+)
+
+// defaultNodesPageSize is the number of nodes read per query when
+// `page_size` is not set.
+const defaultNodesPageSize = 100
+
+// NewNodesDataSource is a helper function to simplify the provider implementation.
+func NewNodesDataSource() datasource.DataSource {
+	return &nodesDataSource{}
+}
+
+type nodesDataSource struct {
+	client   *graphql.Client
+	schema   schemaAPI
+	Kind     types.String  `tfsdk:"kind"`
+	Filters  types.Map     `tfsdk:"filters"`
+	Limit    types.Int64   `tfsdk:"limit"`
+	PageSize types.Int64   `tfsdk:"page_size"`
+	Count    types.Int64   `tfsdk:"count"`
+	Nodes    types.Dynamic `tfsdk:"nodes"`
+}
+
+func (d *nodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nodes"
+}
+
+func (d *nodesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the nodes of any kind matching filters, reading them page by page. The query is built " +
+			"from the schema of the server, so kinds added by schema extensions can be read without a dedicated data source.",
+		Attributes: map[string]schema.Attribute{
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the nodes, e.g. `SecurityZone`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(kindPattern, "must be a kind, e.g. `SecurityZone`"),
+				},
+			},
+			"filters": schema.MapAttribute{
+				MarkdownDescription: nodeFiltersDescription,
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of nodes returned, all the matching nodes by default",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of nodes read per query, defaults to %d", defaultNodesPageSize),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"count": schema.Int64Attribute{
+				MarkdownDescription: "Number of nodes matching the filters, including the nodes beyond `limit`",
+				Computed:            true,
+			},
+			"nodes": schema.DynamicAttribute{
+				MarkdownDescription: "Matching nodes, each with `id`, `hfid`, `display_label`, `attributes` holding the values " +
+					"of the attributes by name and `relationships` holding the IDs of the peers by name",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *nodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading nodes data...")
+	var config nodesDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := config.Kind.ValueString()
+	schemaNode, err := d.schema.node(ctx, kind)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read schema from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	if schemaNode == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kind"),
+			"Unknown kind",
+			fmt.Sprintf("%s is not a kind of the schema of the server", kind),
+		)
+		return
+	}
+
+	filters := nodeFilters(schemaNode, config.Filters, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int(config.Limit.ValueInt64())
+	pageSize := defaultNodesPageSize
+	if !config.PageSize.IsNull() {
+		pageSize = int(config.PageSize.ValueInt64())
+	}
+
+	count := 0
+	nodes := []map[string]any{}
+	for {
+		size := pageSize
+		if limit > 0 {
+			size = min(size, limit-len(nodes))
+		}
+		arguments := append(slices.Clone(filters), fmt.Sprintf("offset: %d", len(nodes)), fmt.Sprintf("limit: %d", size))
+
+		tflog.Debug(ctx, fmt.Sprintf("Reading %s from offset %d", kind, len(nodes)))
+		total, page, err := queryNodes(ctx, *d.client, schemaNode, arguments)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read "+kind+" from Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		count = total
+		nodes = append(nodes, page...)
+
+		// Stop on the last page, also when nodes are deleted while reading
+		if len(page) == 0 || len(nodes) >= count || (limit > 0 && len(nodes) >= limit) {
+			break
+		}
+	}
+
+	elementTypes := []attr.Type{}
+	elements := []attr.Value{}
+	for _, node := range nodes {
+		element := nodeObject(nodeValues(schemaNode, node))
+		elementTypes = append(elementTypes, element.Type(ctx))
+		elements = append(elements, element)
+	}
+	list, diags := types.TupleValue(elementTypes, elements)
+	resp.Diagnostics.Append(diags...)
+
+	state := nodesDataSource{
+		Kind:     config.Kind,
+		Filters:  config.Filters,
+		Limit:    config.Limit,
+		PageSize: config.PageSize,
+		Count:    types.Int64Value(int64(count)),
+		Nodes:    types.DynamicValue(list),
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+	d.schema = providerSchemaAPI(req.ProviderData)
+}
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &numberAllocationResource{}
+	_ resource.ResourceWithConfigure = &numberAllocationResource{}
+)
+
+// NewNumberAllocationResource is a helper function to simplify the provider implementation.
+func NewNumberAllocationResource() resource.Resource {
+	return &numberAllocationResource{}
+}
+
+// numberAllocationResource allocates a number out of a CoreNumberPool. Infrahub
+// only hands out numbers while creating the node the pool is bound to, so the
+// resource creates that node with the pool attribute taken from the pool.
+type numberAllocationResource struct {
+	client        *graphql.Client
+	Id            types.String `tfsdk:"id"`
+	PoolId        types.String `tfsdk:"pool_id"`
+	Identifier    types.String `tfsdk:"identifier"`
+	Attributes    types.Map    `tfsdk:"attributes"`
+	Number        types.Int64  `tfsdk:"number"`
+	Kind          types.String `tfsdk:"kind"`
+	NodeAttribute types.String `tfsdk:"node_attribute"`
+}
+
+// Kinds and attribute names are interpolated into the operations below, only
+// accept what the Infrahub schema allows for them.
+var (
+	numberAllocationKind      = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	numberAllocationAttribute = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+type numberAllocationNode struct {
+	Id     string `json:"id"`
+	Number struct {
+		Value json.Number `json:"value"`
+	} `json:"number"`
+}
+
+// Metadata returns the resource type name.
+func (r *numberAllocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_number_allocation"
+}
+
+// Schema defines the schema for the resource.
+func (r *numberAllocationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Allocates a number from a `CoreNumberPool` by creating the node the pool is bound to. " +
+			"Allocations are keyed by `identifier`, so applying again with the same identifier returns the same number.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the node holding the allocated number",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pool_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the CoreNumberPool to allocate from",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identifier": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the allocation within the pool",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Other attribute values of the created node, e.g. `name` for an `InfraAutonomousSystem`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"number": schema.Int64Attribute{
+				MarkdownDescription: "Allocated number",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the node the pool allocates numbers for",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_attribute": schema.StringAttribute{
+				MarkdownDescription: "Attribute of the node the number is allocated to",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create allocates a number from the pool and sets the initial Terraform state.
+func (r *numberAllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan numberAllocationResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool, err := infrahub_sdk.NumberPool(ctx, *r.client, plan.PoolId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read number pool from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	if len(pool.CoreNumberPool.Edges) != 1 {
+		resp.Diagnostics.AddError(
+			"Didn't receive a single number pool, query didn't return exactly 1 number pool",
+			fmt.Sprintf("Expected exactly 1 number pool with ID %s, got a different count.", plan.PoolId.ValueString()),
+		)
+		return
+	}
+
+	kind := pool.CoreNumberPool.Edges[0].Node.Node.Value
+	attribute := pool.CoreNumberPool.Edges[0].Node.Node_attribute.Value
+	if !numberAllocationKind.MatchString(kind) || !numberAllocationAttribute.MatchString(attribute) {
+		resp.Diagnostics.AddError(
+			"Unsupported number pool",
+			fmt.Sprintf("Number pool %s allocates to %q.%q, which is not a valid kind and attribute.", plan.PoolId.ValueString(), kind, attribute),
+		)
+		return
+	}
+
+	attributes := map[string]string{}
+	diags = plan.Attributes.ElementsAs(ctx, &attributes, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := map[string]any{}
+	for name, value := range attributes {
+		data[name] = map[string]any{"value": value}
+	}
+	data[attribute] = map[string]any{
+		"from_pool": map[string]any{
+			"id":         plan.PoolId.ValueString(),
+			"identifier": plan.Identifier.ValueString(),
+		},
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Allocating %s.%s %s from pool %s", kind, attribute, plan.Identifier.ValueString(), plan.PoolId.ValueString()))
+
+	var response struct {
+		Allocation struct {
+			Ok     bool                 `json:"ok"`
+			Object numberAllocationNode `json:"object"`
+		} `json:"allocation"`
+	}
+	err = (*r.client).MakeRequest(ctx, &graphql.Request{
+		OpName: "NumberAllocate",
+		Query: fmt.Sprintf(
+			`mutation NumberAllocate($data: %[1]sCreateInput!) { allocation: %[1]sCreate(data: $data) { ok object { id number: %[2]s { value } } } }`,
+			kind, attribute,
+		),
+		Variables: map[string]any{"data": data},
+	}, &graphql.Response{Data: &response})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to allocate number in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	number, err := response.Allocation.Object.Number.Value.Int64()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to allocate number in Infrahub",
+			fmt.Sprintf("Pool %s didn't return a number for identifier %s, it may be exhausted.", plan.PoolId.ValueString(), plan.Identifier.ValueString()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(response.Allocation.Object.Id)
+	plan.Number = types.Int64Value(number)
+	plan.Kind = types.StringValue(kind)
+	plan.NodeAttribute = types.StringValue(attribute)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *numberAllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading NumberAllocation...")
+	var state numberAllocationResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !numberAllocationKind.MatchString(state.Kind.ValueString()) || !numberAllocationAttribute.MatchString(state.NodeAttribute.ValueString()) {
+		resp.Diagnostics.AddError(
+			"Unable to read allocated number from Infrahub",
+			fmt.Sprintf("State holds an invalid kind and attribute %q.%q.", state.Kind.ValueString(), state.NodeAttribute.ValueString()),
+		)
+		return
+	}
+
+	var response struct {
+		Allocation struct {
+			Edges []struct {
+				Node numberAllocationNode `json:"node"`
+			} `json:"edges"`
+		} `json:"allocation"`
+	}
+	err := (*r.client).MakeRequest(ctx, &graphql.Request{
+		OpName: "NumberAllocation",
+		Query: fmt.Sprintf(
+			`query NumberAllocation($id: ID!) { allocation: %[1]s(ids: [$id]) { edges { node { id number: %[2]s { value } } } } }`,
+			state.Kind.ValueString(), state.NodeAttribute.ValueString(),
+		),
+		Variables: map[string]any{"id": state.Id.ValueString()},
+	}, &graphql.Response{Data: &response})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read allocated number from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The node was deleted outside of Terraform, allocate again on the next apply
+	if len(response.Allocation.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	number, err := response.Allocation.Edges[0].Node.Number.Value.Int64()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read allocated number from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	state.Number = types.Int64Value(number)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called with changes since every argument forces a new allocation.
+func (r *numberAllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan numberAllocationResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the node holding the number, which releases it back to the pool.
+func (r *numberAllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state numberAllocationResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !numberAllocationKind.MatchString(state.Kind.ValueString()) {
+		resp.Diagnostics.AddError(
+			"Error Deleting NumberAllocation",
+			fmt.Sprintf("State holds an invalid kind %q.", state.Kind.ValueString()),
+		)
+		return
+	}
+
+	var response struct {
+		Allocation struct {
+			Ok bool `json:"ok"`
+		} `json:"allocation"`
+	}
+	err := (*r.client).MakeRequest(ctx, &graphql.Request{
+		OpName:    "NumberAllocationDelete",
+		Query:     fmt.Sprintf(`mutation NumberAllocationDelete($id: String!) { allocation: %sDelete(data: {id: $id}) { ok } }`, state.Kind.ValueString()),
+		Variables: map[string]any{"id": state.Id.ValueString()},
+	}, &graphql.Response{Data: &response})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting NumberAllocation",
+			"Could not delete allocated number, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *numberAllocationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
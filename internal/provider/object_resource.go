@@ -0,0 +1,521 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &objectResource{}
+	_ resource.ResourceWithConfigure   = &objectResource{}
+	_ resource.ResourceWithImportState = &objectResource{}
+	_ resource.ResourceWithModifyPlan  = &objectResource{}
+)
+
+// NewObjectResource is a helper function to simplify the provider implementation.
+func NewObjectResource() resource.Resource {
+	return &objectResource{}
+}
+
+// objectResource is the resource implementation. It manages a node of any
+// kind with mutations built at runtime from the schema of the server.
+type objectResource struct {
+	client        *graphql.Client
+	schema        schemaAPI
+	choices       *schemaChoices
+	Id            types.String  `tfsdk:"id"`
+	Kind          types.String  `tfsdk:"kind"`
+	Attributes    types.Dynamic `tfsdk:"attributes"`
+	Relationships types.Map     `tfsdk:"relationships"`
+	Hfid          types.List    `tfsdk:"hfid"`
+	DisplayLabel  types.String  `tfsdk:"display_label"`
+}
+
+// objectFields are the fields returned by the mutations of an object.
+type objectFields struct {
+	Id           string   `json:"id"`
+	Hfid         []string `json:"hfid"`
+	DisplayLabel string   `json:"display_label"`
+}
+
+// Metadata returns the resource type name.
+func (r *objectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object"
+}
+
+// Schema defines the schema for the resource.
+func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a node of any kind, for the kinds without a dedicated resource. The mutations are " +
+			"built from the schema of the server, so the attributes and relationships are only checked once the " +
+			"provider is configured. Attributes and relationships removed from the configuration keep their value " +
+			"in Infrahub. Import with `<kind>/<id>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the node",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the node, e.g. `SecurityZone`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(kindPattern, "must be a kind, e.g. `SecurityZone`"),
+				},
+			},
+			"attributes": schema.DynamicAttribute{
+				MarkdownDescription: "Values of the attributes of the node by attribute name, e.g. `{ name = \"dmz\", trust = 10 }`",
+				Optional:            true,
+			},
+			"relationships": schema.MapAttribute{
+				MarkdownDescription: "IDs of the peers of the node by relationship name, a single ID for the relationships of cardinality one",
+				Optional:            true,
+				ElementType:         types.SetType{ElemType: types.StringType},
+			},
+			"hfid": schema.ListAttribute{
+				MarkdownDescription: "Human friendly ID of the node",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_label": schema.StringAttribute{
+				MarkdownDescription: "Label of the node in the UI",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan objectResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := plan.Kind.ValueString()
+	node := r.node(ctx, kind, &resp.Diagnostics)
+	if node == nil {
+		return
+	}
+	data := plan.data(node, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating %s", kind))
+
+	var response map[string]struct {
+		Object objectFields `json:"object"`
+	}
+	query := fmt.Sprintf("mutation ObjectCreate($data: %[1]sCreateInput!) { %[1]sCreate(data: $data) { object { id hfid display_label } } }", kind)
+	err := graphqlRequest(ctx, *r.client, "ObjectCreate", query, map[string]any{"data": data}, &response)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create "+kind+" in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response[kind+"Create"].Object)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *objectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading object...")
+	var state objectResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := state.Kind.ValueString()
+	schemaNode := r.node(ctx, kind, &resp.Diagnostics)
+	if schemaNode == nil {
+		return
+	}
+
+	attributes, err := dynamicElements(state.Attributes)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("attributes"), "Invalid attributes", err.Error())
+		return
+	}
+	relationships := state.Relationships.Elements()
+
+	var response map[string]struct {
+		Edges []struct {
+			Node map[string]any `json:"node"`
+		} `json:"edges"`
+	}
+	selection := nodeSelection(schemaNode, slices.Sorted(maps.Keys(attributes)), slices.Sorted(maps.Keys(relationships)))
+	query := fmt.Sprintf("query ObjectRead($ids: [ID]) { %s(ids: $ids) { edges { node { %s } } } }", kind, selection)
+	err = graphqlRequest(ctx, *r.client, "ObjectRead", query, map[string]any{"ids": []string{state.Id.ValueString()}}, &response)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read "+kind+" from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The node was deleted outside of Terraform
+	if len(response[kind].Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	node := response[kind].Edges[0].Node
+
+	hfid := []string{}
+	if values, ok := node["hfid"].([]any); ok {
+		for _, value := range values {
+			hfid = append(hfid, fmt.Sprint(value))
+		}
+	}
+	label, _ := node["display_label"].(string)
+	state.fill(objectFields{Id: state.Id.ValueString(), Hfid: hfid, DisplayLabel: label})
+
+	if len(attributes) > 0 {
+		refreshed := map[string]any{}
+		for name := range attributes {
+			refreshed[name] = attributeValue(node, name)
+		}
+		// The prior value guides the types of the refreshed attributes
+		state.Attributes = refreshedValue(state.Attributes, refreshed).(types.Dynamic)
+	}
+
+	if len(relationships) > 0 {
+		refreshed := map[string]attr.Value{}
+		for name := range relationships {
+			ids, diags := types.SetValueFrom(ctx, types.StringType, relationshipIDs(node, name))
+			resp.Diagnostics.Append(diags...)
+			refreshed[name] = ids
+		}
+		state.Relationships, diags = types.MapValue(types.SetType{ElemType: types.StringType}, refreshed)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan objectResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state objectResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := plan.Kind.ValueString()
+	node := r.node(ctx, kind, &resp.Diagnostics)
+	if node == nil {
+		return
+	}
+
+	// Clear the relationships removed from the configuration, the peers
+	// would otherwise stay while Terraform forgets them
+	cleared := []string{}
+	for name := range state.Relationships.Elements() {
+		if _, ok := plan.Relationships.Elements()[name]; !ok {
+			cleared = append(cleared, name)
+		}
+	}
+	data := plan.data(node, cleared, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data["id"] = state.Id.ValueString()
+
+	tflog.Info(ctx, fmt.Sprintf("Updating %s %s", kind, state.Id.ValueString()))
+
+	var response map[string]struct {
+		Object objectFields `json:"object"`
+	}
+	query := fmt.Sprintf("mutation ObjectUpdate($data: %[1]sUpdateInput!) { %[1]sUpdate(data: $data) { object { id hfid display_label } } }", kind)
+	err := graphqlRequest(ctx, *r.client, "ObjectUpdate", query, map[string]any{"data": data}, &response)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update "+kind+" in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response[kind+"Update"].Object)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *objectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state objectResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := state.Kind.ValueString()
+	var response map[string]any
+	query := fmt.Sprintf("mutation ObjectDelete($id: String!) { %sDelete(data: {id: $id}) { ok } }", kind)
+	err := graphqlRequest(ctx, *r.client, "ObjectDelete", query, map[string]any{"id": state.Id.ValueString()}, &response)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting "+kind,
+			"Could not delete "+kind+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ModifyPlan fails the plan when the attributes or relationships are missing
+// from the schema of the kind, or when a value is not a choice of its
+// dropdown.
+func (r *objectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.schema.client == nil {
+		return
+	}
+
+	var plan objectResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Kind.IsUnknown() || plan.Attributes.IsUnknown() || plan.Relationships.IsUnknown() {
+		return
+	}
+
+	node := r.node(ctx, plan.Kind.ValueString(), &resp.Diagnostics)
+	if node == nil {
+		return
+	}
+	attributes, err := dynamicElements(plan.Attributes)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("attributes"), "Invalid attributes", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(checkObjectNames(node, attributes, plan.Relationships.Elements())...)
+
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		value, ok := attributes[name].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if err := r.choices.allowed(plan.Kind.ValueString(), name, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("attributes"), "Invalid choice", err.Error())
+		}
+	}
+}
+
+// ImportState imports a node by `<kind>/<id>`.
+func (r *objectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	kind, id, ok := strings.Cut(req.ID, "/")
+	if !ok || !kindPattern.MatchString(kind) || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected <kind>/<id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), kind)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *objectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.schema = providerSchemaAPI(req.ProviderData)
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// node returns the schema of a kind, nil with an error when it cannot be
+// read or the kind does not exist.
+func (r *objectResource) node(ctx context.Context, kind string, diags *diag.Diagnostics) *schemaNode {
+	node, err := r.schema.node(ctx, kind)
+	if err != nil {
+		diags.AddError(
+			"Unable to read schema from Infrahub",
+			err.Error(),
+		)
+		return nil
+	}
+	if node == nil {
+		diags.AddAttributeError(
+			path.Root("kind"),
+			"Unknown kind",
+			fmt.Sprintf("%s is not a kind of the schema of the server", kind),
+		)
+	}
+	return node
+}
+
+// data returns the data of the Create and Update mutations of the node.
+// cleared lists the relationships whose peers are removed.
+func (r *objectResource) data(node *schemaNode, cleared []string, diags *diag.Diagnostics) map[string]any {
+	attributes, err := dynamicElements(r.Attributes)
+	if err != nil {
+		diags.AddAttributeError(path.Root("attributes"), "Invalid attributes", err.Error())
+		return nil
+	}
+	relationships := r.Relationships.Elements()
+	diags.Append(checkObjectNames(node, attributes, relationships)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	data := map[string]any{}
+	for name, attribute := range attributes {
+		value, err := jsonValue(attribute)
+		if err != nil {
+			diags.AddAttributeError(path.Root("attributes"), "Invalid attribute value", fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+		data[name] = map[string]any{"value": value}
+	}
+
+	for name, element := range relationships {
+		peers := []map[string]any{}
+		for _, id := range element.(types.Set).Elements() {
+			peers = append(peers, map[string]any{"id": id.(types.String).ValueString()})
+		}
+		if node.relationship(name).Cardinality != cardinalityOne {
+			data[name] = peers
+			continue
+		}
+		switch len(peers) {
+		case 0:
+			data[name] = nil
+		case 1:
+			data[name] = peers[0]
+		default:
+			diags.AddAttributeError(
+				path.Root("relationships").AtMapKey(name),
+				"Too many peers",
+				fmt.Sprintf("%s of %s has a cardinality of one, got %d IDs", name, node.Kind, len(peers)),
+			)
+		}
+	}
+
+	for _, name := range cleared {
+		if relationship := node.relationship(name); relationship == nil {
+			continue
+		} else if relationship.Cardinality == cardinalityOne {
+			data[name] = nil
+		} else {
+			data[name] = []map[string]any{}
+		}
+	}
+	return data
+}
+
+// fill sets the computed attributes from the fields returned by the server.
+func (r *objectResource) fill(fields objectFields) {
+	r.Id = types.StringValue(fields.Id)
+	hfid := []attr.Value{}
+	for _, value := range fields.Hfid {
+		hfid = append(hfid, types.StringValue(value))
+	}
+	r.Hfid = types.ListValueMust(types.StringType, hfid)
+	r.DisplayLabel = types.StringValue(fields.DisplayLabel)
+}
+
+// checkObjectNames reports the attributes and relationships missing from the
+// schema of a node.
+func checkObjectNames(node *schemaNode, attributes map[string]attr.Value, relationships map[string]attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		if !namePattern.MatchString(name) || !node.hasAttribute(name) {
+			diags.AddAttributeError(
+				path.Root("attributes"),
+				"Unknown attribute",
+				fmt.Sprintf("%s is not an attribute of %s", name, node.Kind),
+			)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(relationships)) {
+		if !namePattern.MatchString(name) || node.relationship(name) == nil {
+			diags.AddAttributeError(
+				path.Root("relationships").AtMapKey(name),
+				"Unknown relationship",
+				fmt.Sprintf("%s is not a relationship of %s", name, node.Kind),
+			)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &organizationDataSource{}
+	_ datasource.DataSourceWithConfigure        = &organizationDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &organizationDataSource{}
+)
+
+// NewManufacturerDataSource is a helper function to simplify the provider implementation.
+func NewManufacturerDataSource() datasource.DataSource {
+	return &organizationDataSource{kind: organizationManufacturer}
+}
+
+// NewProviderOrganizationDataSource is a helper function to simplify the provider implementation.
+func NewProviderOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{kind: organizationProvider}
+}
+
+// NewTenantDataSource is a helper function to simplify the provider implementation.
+func NewTenantDataSource() datasource.DataSource {
+	return &organizationDataSource{kind: organizationTenant}
+}
+
+type organizationDataSource struct {
+	client      *graphql.Client
+	kind        organizationKind
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *organizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.kind.name
+}
+
+func (d *organizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Looks up an `%s` by ID or by name.", d.kind.kind),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization, conflicts with `name`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the organization, conflicts with `id`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *organizationDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, fmt.Sprintf("Reading %s data...", d.kind.kind))
+	config := organizationDataSource{}
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nodes []infrahub_sdk.OrganizationFields
+	if config.Id.ValueString() != "" {
+		response, err := infrahub_sdk.Organization(ctx, *d.client, config.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to read %s from Infrahub", d.kind.name),
+				err.Error(),
+			)
+			return
+		}
+		for _, edge := range response.OrganizationGeneric.Edges {
+			if fields, ok := edge.Node.(infrahub_sdk.OrganizationFields); ok {
+				nodes = append(nodes, fields)
+			}
+		}
+	} else {
+		response, err := infrahub_sdk.OrganizationLookup(ctx, *d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to read %s from Infrahub", d.kind.name),
+				err.Error(),
+			)
+			return
+		}
+		for _, edge := range response.OrganizationGeneric.Edges {
+			if fields, ok := edge.Node.(infrahub_sdk.OrganizationFields); ok {
+				nodes = append(nodes, fields)
+			}
+		}
+	}
+
+	// Organizations of other kinds may share the name
+	var state *organizationDataSource
+	for _, node := range nodes {
+		if node.GetTypename() == d.kind.kind {
+			var organization organizationResource
+			organization.fill(node, nil)
+			state = &organizationDataSource{
+				Id:          organization.Id,
+				Name:        organization.Name,
+				Description: organization.Description,
+			}
+		}
+	}
+	if state == nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("%s not found", d.kind.kind),
+			fmt.Sprintf("No %s matches id %q or name %q", d.kind.kind, config.Id.ValueString(), config.Name.ValueString()),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *organizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
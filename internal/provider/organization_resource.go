@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &organizationResource{}
+	_ resource.ResourceWithConfigure   = &organizationResource{}
+	_ resource.ResourceWithImportState = &organizationResource{}
+	_ resource.ResourceWithModifyPlan  = &organizationResource{}
+)
+
+// organizationKind describes one OrganizationGeneric kind. All kinds share
+// the same attributes, but Infrahub generates separate mutations for each.
+type organizationKind struct {
+	name        string
+	kind        string
+	description string
+	deprecation string
+	create      func(ctx context.Context, client graphql.Client, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error)
+	update      func(ctx context.Context, client graphql.Client, id string, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error)
+	delete      func(ctx context.Context, client graphql.Client, id string) error
+}
+
+var (
+	organizationManufacturer = organizationKind{
+		name:        "manufacturer",
+		kind:        "OrganizationManufacturer",
+		description: "the manufacturer of device types and platforms",
+		create: func(ctx context.Context, client graphql.Client, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.OrganizationManufacturerCreate(ctx, client, name, description, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.OrganizationManufacturerCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, id string, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.OrganizationManufacturerUpdate(ctx, client, id, name, description, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.OrganizationManufacturerUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.OrganizationManufacturerDelete(ctx, client, id)
+			return err
+		},
+	}
+	organizationProvider = organizationKind{
+		name:        "provider_organization",
+		kind:        "OrganizationProvider",
+		description: "the carrier or service provider of circuits",
+		create: func(ctx context.Context, client graphql.Client, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.OrganizationProviderCreate(ctx, client, name, description, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.OrganizationProviderCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, id string, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.OrganizationProviderUpdate(ctx, client, id, name, description, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.OrganizationProviderUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.OrganizationProviderDelete(ctx, client, id)
+			return err
+		},
+	}
+	organizationTenant = organizationKind{
+		name:        "tenant",
+		kind:        "OrganizationTenant",
+		description: "the tenant owning locations, circuits and prefixes",
+		create: func(ctx context.Context, client graphql.Client, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.OrganizationTenantCreate(ctx, client, name, description, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.OrganizationTenantCreate.Object.Id, nil
+		},
+		update: func(ctx context.Context, client graphql.Client, id string, name string, description string, tags infrahub_sdk.RelatedNodes) (string, error) {
+			response, err := infrahub_sdk.OrganizationTenantUpdate(ctx, client, id, name, description, tags)
+			if err != nil {
+				return "", err
+			}
+			return response.OrganizationTenantUpdate.Object.Id, nil
+		},
+		delete: func(ctx context.Context, client graphql.Client, id string) error {
+			_, err := infrahub_sdk.OrganizationTenantDelete(ctx, client, id)
+			return err
+		},
+	}
+)
+
+// NewManufacturerResource is a helper function to simplify the provider implementation.
+func NewManufacturerResource() resource.Resource {
+	return &organizationResource{kind: organizationManufacturer}
+}
+
+// NewProviderOrganizationResource is a helper function to simplify the provider implementation.
+func NewProviderOrganizationResource() resource.Resource {
+	return &organizationResource{kind: organizationProvider}
+}
+
+// NewProviderOrgResource keeps the infrahub_provider_org name working, it
+// manages the same kind as infrahub_provider_organization.
+func NewProviderOrgResource() resource.Resource {
+	kind := organizationProvider
+	kind.name = "provider_org"
+	kind.deprecation = "Use the infrahub_provider_organization resource instead."
+	return &organizationResource{kind: kind}
+}
+
+// NewTenantResource is a helper function to simplify the provider implementation.
+func NewTenantResource() resource.Resource {
+	return &organizationResource{kind: organizationTenant}
+}
+
+// organizationResource is the resource implementation shared by all organization kinds.
+type organizationResource struct {
+	client      *graphql.Client
+	kind        organizationKind
+	defaultTags []string
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Tags        types.Set    `tfsdk:"tags"`
+	TagsAll     types.Set    `tfsdk:"tags_all"`
+}
+
+// Metadata returns the resource type name.
+func (r *organizationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.name
+}
+
+// Schema defines the schema for the resource.
+func (r *organizationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages an `%s`, %s.", r.kind.kind, r.kind.description),
+		DeprecationMessage:  r.kind.deprecation,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": optionalComputedString(""),
+			"tags":        tagsAttribute(),
+			"tags_all":    tagsAllAttribute(),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	plan := organizationResource{}
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating ", r.kind.kind, " ", plan.Name))
+
+	id, err := r.kind.create(ctx, *r.client, plan.Name.ValueString(), plan.Description.ValueString(), relatedNodes(mergeTags(plan.Tags, r.defaultTags)))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create %s in Infrahub", r.kind.name),
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, id, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, fmt.Sprintf("Reading %s...", r.kind.kind))
+	state := organizationResource{}
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, state.Id.ValueString(), &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The organization was deleted outside of Terraform
+	if state.Id.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	plan := organizationResource{}
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating %s %s", r.kind.kind, plan.Name.ValueString()))
+
+	id, err := r.kind.update(ctx, *r.client, plan.Id.ValueString(), plan.Name.ValueString(), plan.Description.ValueString(), relatedNodes(mergeTags(plan.Tags, r.defaultTags)))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to update %s in Infrahub", r.kind.name),
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, id, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	state := organizationResource{}
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.kind.delete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting "+r.kind.kind,
+			fmt.Sprintf("Could not delete %s, unexpected error: %s", r.kind.name, err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports an organization by its node ID.
+func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *organizationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.defaultTags = providerDefaultTags(req.ProviderData)
+}
+
+// ModifyPlan adds the provider default tags to tags_all.
+func (r *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planTagsAll(ctx, req, resp, r.defaultTags)...)
+}
+
+// read fetches the organization from Infrahub and copies it into the model.
+// The ID is set to null when the organization no longer exists or has
+// another kind.
+func (r *organizationResource) read(ctx context.Context, id string, m *organizationResource) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	response, err := infrahub_sdk.Organization(ctx, *r.client, id)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to read %s from Infrahub", r.kind.name),
+			err.Error(),
+		)
+		return diags
+	}
+
+	m.Id = types.StringNull()
+	for _, edge := range response.OrganizationGeneric.Edges {
+		if fields, ok := edge.Node.(infrahub_sdk.OrganizationFields); ok && fields.GetTypename() == r.kind.kind {
+			m.fill(fields, r.defaultTags)
+		}
+	}
+	return diags
+}
+
+// fill copies the fields returned by Infrahub into the resource model,
+// leaving the default tags out of tags.
+func (r *organizationResource) fill(fields infrahub_sdk.OrganizationFields, defaultTags []string) {
+	tags := []string{}
+	for _, edge := range fields.GetTags().Edges {
+		tags = append(tags, edge.Node.Id)
+	}
+
+	r.Id = types.StringValue(fields.GetId())
+	r.Name = types.StringValue(fields.GetName().Value)
+	r.Description = types.StringValue(fields.GetDescription().Value)
+	r.Tags = resourceTags(tags, r.Tags, defaultTags)
+	r.TagsAll = idSet(tags...)
+}
@@ -0,0 +1,262 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &platformResource{}
+	_ resource.ResourceWithConfigure   = &platformResource{}
+	_ resource.ResourceWithImportState = &platformResource{}
+)
+
+// NewPlatformResource is a helper function to simplify the provider implementation.
+func NewPlatformResource() resource.Resource {
+	return &platformResource{}
+}
+
+// platformResource is the resource implementation.
+type platformResource struct {
+	client            *graphql.Client
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Description       types.String `tfsdk:"description"`
+	NornirPlatform    types.String `tfsdk:"nornir_platform"`
+	NapalmDriver      types.String `tfsdk:"napalm_driver"`
+	NetmikoDeviceType types.String `tfsdk:"netmiko_device_type"`
+	AnsibleNetworkOs  types.String `tfsdk:"ansible_network_os"`
+	ContainerlabOs    types.String `tfsdk:"containerlab_os"`
+	ManufacturerId    types.String `tfsdk:"manufacturer_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *platformResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platform"
+}
+
+// Schema defines the schema for the resource.
+func (r *platformResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraPlatform`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description":         optionalComputedString(""),
+			"nornir_platform":     optionalComputedString("Platform name used by Nornir"),
+			"napalm_driver":       optionalComputedString("NAPALM driver, e.g. `eos`"),
+			"netmiko_device_type": optionalComputedString("Netmiko device type, e.g. `arista_eos`"),
+			"ansible_network_os":  optionalComputedString("Ansible network OS, e.g. `arista.eos.eos`"),
+			"containerlab_os":     optionalComputedString("Containerlab kind, e.g. `ceos`"),
+			"manufacturer_id":     optionalComputedString("ID of the manufacturer"),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *platformResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan platformResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating Platform ", plan.Name))
+
+	response, err := infrahub_sdk.PlatformCreate(
+		ctx,
+		*r.client,
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		plan.NornirPlatform.ValueString(),
+		plan.NapalmDriver.ValueString(),
+		plan.NetmikoDeviceType.ValueString(),
+		plan.AnsibleNetworkOs.ValueString(),
+		plan.ContainerlabOs.ValueString(),
+		plan.ManufacturerId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create platform in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraPlatformCreate.Object.PlatformFields)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *platformResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading Platform...")
+	var state platformResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.PlatformNode(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read platform from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The platform was deleted outside of Terraform
+	if len(response.InfraPlatform.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.fill(response.InfraPlatform.Edges[0].Node.PlatformFields)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *platformResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan platformResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state platformResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating Platform %s", state.Name.ValueString()))
+
+	response, err := infrahub_sdk.PlatformUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		plan.NornirPlatform.ValueString(),
+		plan.NapalmDriver.ValueString(),
+		plan.NetmikoDeviceType.ValueString(),
+		plan.AnsibleNetworkOs.ValueString(),
+		plan.ContainerlabOs.ValueString(),
+		infrahub_sdk.RelatedNode{Id: plan.ManufacturerId.ValueString()},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update platform in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraPlatformUpdate.Object.PlatformFields)
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *platformResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state platformResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.PlatformDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Platform",
+			"Could not delete platform, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a platform by its node ID.
+func (r *platformResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *platformResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *platformResource) fill(fields infrahub_sdk.PlatformFields) {
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.NornirPlatform = types.StringValue(fields.Nornir_platform.Value)
+	r.NapalmDriver = types.StringValue(fields.Napalm_driver.Value)
+	r.NetmikoDeviceType = types.StringValue(fields.Netmiko_device_type.Value)
+	r.AnsibleNetworkOs = types.StringValue(fields.Ansible_network_os.Value)
+	r.ContainerlabOs = types.StringValue(fields.Containerlab_os.Value)
+	r.ManufacturerId = types.StringValue(fields.Manufacturer.Node.Id)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &poolUtilizationDataSource{}
+	_ datasource.DataSourceWithConfigure = &poolUtilizationDataSource{}
+)
+
+// NewPoolUtilizationDataSource is a helper function to simplify the provider implementation.
+func NewPoolUtilizationDataSource() datasource.DataSource {
+	return &poolUtilizationDataSource{}
+}
+
+type poolUtilizationDataSource struct {
+	client                   *graphql.Client
+	PoolId                   types.String                   `tfsdk:"pool_id"`
+	Count                    types.Int64                    `tfsdk:"count"`
+	Utilization              types.Float64                  `tfsdk:"utilization"`
+	UtilizationBranches      types.Float64                  `tfsdk:"utilization_branches"`
+	UtilizationDefaultBranch types.Float64                  `tfsdk:"utilization_default_branch"`
+	Resources                []poolUtilizationResourceModel `tfsdk:"resources"`
+}
+
+type poolUtilizationResourceModel struct {
+	Id                       types.String  `tfsdk:"id"`
+	DisplayLabel             types.String  `tfsdk:"display_label"`
+	Kind                     types.String  `tfsdk:"kind"`
+	Weight                   types.Int64   `tfsdk:"weight"`
+	Utilization              types.Float64 `tfsdk:"utilization"`
+	UtilizationBranches      types.Float64 `tfsdk:"utilization_branches"`
+	UtilizationDefaultBranch types.Float64 `tfsdk:"utilization_default_branch"`
+}
+
+func (d *poolUtilizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pool_utilization"
+}
+
+func (d *poolUtilizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Utilization of a resource pool, e.g. a `CoreNumberPool` or `CoreIPPrefixPool`.",
+		Attributes: map[string]schema.Attribute{
+			"pool_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the resource pool",
+				Required:            true,
+			},
+			"count": schema.Int64Attribute{
+				MarkdownDescription: "Number of resources within the pool",
+				Computed:            true,
+			},
+			"utilization": schema.Float64Attribute{
+				MarkdownDescription: "Overall utilization of the pool in percent",
+				Computed:            true,
+			},
+			"utilization_branches": schema.Float64Attribute{
+				MarkdownDescription: "Utilization in all non default branches in percent",
+				Computed:            true,
+			},
+			"utilization_default_branch": schema.Float64Attribute{
+				MarkdownDescription: "Utilization in the default branch in percent",
+				Computed:            true,
+			},
+			"resources": schema.ListNestedAttribute{
+				MarkdownDescription: "Utilization of each resource the pool allocates from",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"display_label": schema.StringAttribute{
+							Computed: true,
+						},
+						"kind": schema.StringAttribute{
+							Computed: true,
+						},
+						"weight": schema.Int64Attribute{
+							Computed: true,
+						},
+						"utilization": schema.Float64Attribute{
+							Computed: true,
+						},
+						"utilization_branches": schema.Float64Attribute{
+							Computed: true,
+						},
+						"utilization_default_branch": schema.Float64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *poolUtilizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading pool utilization data...")
+	var config poolUtilizationDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	response, err := infrahub_sdk.PoolUtilization(ctx, *d.client, config.PoolId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read pool utilization from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	utilization := response.InfrahubResourcePoolUtilization
+	state := poolUtilizationDataSource{
+		PoolId:                   config.PoolId,
+		Count:                    types.Int64Value(int64(utilization.Count)),
+		Utilization:              types.Float64Value(utilization.Utilization),
+		UtilizationBranches:      types.Float64Value(utilization.Utilization_branches),
+		UtilizationDefaultBranch: types.Float64Value(utilization.Utilization_default_branch),
+		Resources:                []poolUtilizationResourceModel{},
+	}
+
+	for _, edge := range utilization.Edges {
+		state.Resources = append(state.Resources, poolUtilizationResourceModel{
+			Id:                       types.StringValue(edge.Node.Id),
+			DisplayLabel:             types.StringValue(edge.Node.Display_label),
+			Kind:                     types.StringValue(edge.Node.Kind),
+			Weight:                   types.Int64Value(int64(edge.Node.Weight)),
+			Utilization:              types.Float64Value(edge.Node.Utilization),
+			UtilizationBranches:      types.Float64Value(edge.Node.Utilization_branches),
+			UtilizationDefaultBranch: types.Float64Value(edge.Node.Utilization_default_branch),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *poolUtilizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
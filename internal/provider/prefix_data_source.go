@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &prefixDataSource{}
+	_ datasource.DataSourceWithConfigure        = &prefixDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &prefixDataSource{}
+)
+
+// NewPrefixDataSource is a helper function to simplify the provider implementation.
+func NewPrefixDataSource() datasource.DataSource {
+	return &prefixDataSource{}
+}
+
+type prefixDataSource struct {
+	client           *graphql.Client
+	Id               types.String   `tfsdk:"id"`
+	Prefix           types.String   `tfsdk:"prefix"`
+	Namespace        types.String   `tfsdk:"namespace"`
+	Description      types.String   `tfsdk:"description"`
+	MemberType       types.String   `tfsdk:"member_type"`
+	IsPool           types.Bool     `tfsdk:"is_pool"`
+	Status           types.String   `tfsdk:"status"`
+	Role             types.String   `tfsdk:"role"`
+	VrfId            types.String   `tfsdk:"vrf_id"`
+	NamespaceId      types.String   `tfsdk:"namespace_id"`
+	PrefixLength     types.Int64    `tfsdk:"prefix_length"`
+	IsTopLevel       types.Bool     `tfsdk:"is_top_level"`
+	Utilization      types.Int64    `tfsdk:"utilization"`
+	Netmask          types.String   `tfsdk:"netmask"`
+	Hostmask         types.String   `tfsdk:"hostmask"`
+	NetworkAddress   types.String   `tfsdk:"network_address"`
+	BroadcastAddress types.String   `tfsdk:"broadcast_address"`
+	ParentId         types.String   `tfsdk:"parent_id"`
+	ChildrenIds      []types.String `tfsdk:"children_ids"`
+}
+
+func (d *prefixDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prefix"
+}
+
+func (d *prefixDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an `InfraPrefix` by ID or by prefix and namespace, including its place in the prefix hierarchy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the prefix, conflicts with `prefix`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Network in CIDR notation, e.g. `10.0.0.0/24`, conflicts with `id`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Name of the IPAM namespace of `prefix`, defaults to `default`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("id")),
+				},
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"member_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"is_pool": schema.BoolAttribute{
+				Computed: true,
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+			"role": schema.StringAttribute{
+				Computed: true,
+			},
+			"vrf_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the VRF the prefix belongs to",
+				Computed:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the IPAM namespace",
+				Computed:            true,
+			},
+			"prefix_length": schema.Int64Attribute{
+				Computed: true,
+			},
+			"is_top_level": schema.BoolAttribute{
+				MarkdownDescription: "Whether the prefix has no parent",
+				Computed:            true,
+			},
+			"utilization": schema.Int64Attribute{
+				MarkdownDescription: "Utilization of the prefix in percent",
+				Computed:            true,
+			},
+			"netmask": schema.StringAttribute{
+				Computed: true,
+			},
+			"hostmask": schema.StringAttribute{
+				Computed: true,
+			},
+			"network_address": schema.StringAttribute{
+				Computed: true,
+			},
+			"broadcast_address": schema.StringAttribute{
+				Computed: true,
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the parent prefix, empty for top level prefixes",
+				Computed:            true,
+			},
+			"children_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the direct child prefixes",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *prefixDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("prefix"),
+		),
+	}
+}
+
+func (d *prefixDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading prefix data...")
+	var config prefixDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefixId, err := resolvePrefixId(ctx, *d.client, config.Id.ValueString(), config.Prefix.ValueString(), config.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read prefix from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	response, err := infrahub_sdk.PrefixDetails(ctx, *d.client, prefixId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read prefix from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	if len(response.InfraPrefix.Edges) != 1 {
+		resp.Diagnostics.AddError(
+			"Didn't receive a single prefix, query didn't return exactly 1 prefix",
+			fmt.Sprintf("Expected exactly 1 prefix with ID %s, got %d.", prefixId, len(response.InfraPrefix.Edges)),
+		)
+		return
+	}
+
+	node := response.InfraPrefix.Edges[0].Node
+	state := prefixDataSource{
+		Id:               types.StringValue(node.Id),
+		Prefix:           types.StringValue(node.PrefixFields.Prefix.Value),
+		Namespace:        config.Namespace,
+		Description:      types.StringValue(node.Description.Value),
+		MemberType:       types.StringValue(node.Member_type.Value),
+		IsPool:           types.BoolValue(node.Is_pool.Value),
+		Status:           types.StringValue(node.Status.Value),
+		Role:             types.StringValue(node.Role.Value),
+		VrfId:            types.StringValue(node.Vrf.Node.Id),
+		NamespaceId:      types.StringValue(nodeId(node.Ip_namespace.Node)),
+		PrefixLength:     types.Int64Value(int64(node.Prefix.Prefixlen)),
+		IsTopLevel:       types.BoolValue(node.Is_top_level.Value),
+		Utilization:      types.Int64Null(),
+		Netmask:          types.StringValue(node.Netmask.Value),
+		Hostmask:         types.StringValue(node.Hostmask.Value),
+		NetworkAddress:   types.StringValue(node.Network_address.Value),
+		BroadcastAddress: types.StringValue(node.Broadcast_address.Value),
+		ParentId:         types.StringValue(nodeId(node.Parent.Node)),
+		ChildrenIds:      []types.String{},
+	}
+
+	if node.Utilization.Value != "" {
+		utilization, err := node.Utilization.Value.Int64()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to parse prefix utilization",
+				err.Error(),
+			)
+			return
+		}
+		state.Utilization = types.Int64Value(utilization)
+	}
+
+	children, err := prefixChildren(ctx, *d.client, node.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read prefix children from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	for _, id := range children {
+		state.ChildrenIds = append(state.ChildrenIds, types.StringValue(id))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *prefixDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
+
+// prefixChildrenPageSize is the number of children read per query, the server
+// caps the size of a page.
+const prefixChildrenPageSize = 100
+
+// prefixChildren returns the IDs of the children of a prefix, reading them
+// page by page.
+func prefixChildren(ctx context.Context, client graphql.Client, prefixId string) ([]string, error) {
+	children := []string{}
+	for {
+		response, err := infrahub_sdk.PrefixChildren(ctx, client, prefixId, len(children), prefixChildrenPageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(response.InfraPrefix.Edges) == 0 {
+			return nil, fmt.Errorf("prefix %s not found", prefixId)
+		}
+
+		page := response.InfraPrefix.Edges[0].Node.Children
+		for _, edge := range page.Edges {
+			children = append(children, nodeId(edge.Node))
+		}
+		// Stop on the last page, also when children are removed while reading
+		if len(page.Edges) == 0 || len(children) >= page.Count {
+			break
+		}
+	}
+	return children, nil
+}
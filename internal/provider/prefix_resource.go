@@ -0,0 +1,348 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &prefixResource{}
+	_ resource.ResourceWithConfigure   = &prefixResource{}
+	_ resource.ResourceWithImportState = &prefixResource{}
+	_ resource.ResourceWithModifyPlan  = &prefixResource{}
+)
+
+// NewPrefixResource is a helper function to simplify the provider implementation.
+func NewPrefixResource() resource.Resource {
+	return &prefixResource{}
+}
+
+// prefixResource is the resource implementation.
+type prefixResource struct {
+	client      *graphql.Client
+	choices     *schemaChoices
+	Id          types.String `tfsdk:"id"`
+	Prefix      types.String `tfsdk:"prefix"`
+	Description types.String `tfsdk:"description"`
+	MemberType  types.String `tfsdk:"member_type"`
+	IsPool      types.Bool   `tfsdk:"is_pool"`
+	Status      types.String `tfsdk:"status"`
+	Role        types.String `tfsdk:"role"`
+	VrfId       types.String `tfsdk:"vrf_id"`
+	NamespaceId types.String `tfsdk:"namespace_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *prefixResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prefix"
+}
+
+// Schema defines the schema for the resource.
+func (r *prefixResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraPrefix`. Import with the node ID or `<namespace>/<prefix>`, e.g. `default/10.0.0.0/24`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Network in CIDR notation, e.g. `10.0.0.0/24`",
+				Required:            true,
+				Validators: []validator.String{
+					cidrValidator{network: true},
+				},
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"member_type": schema.StringAttribute{
+				MarkdownDescription: "Whether the prefix holds child `prefix`es or `address`es",
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("prefix", "address"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_pool": schema.BoolAttribute{
+				MarkdownDescription: "All IP addresses within this prefix are considered usable",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vrf_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the VRF the prefix belongs to, removing it detaches the prefix from the VRF",
+				Optional:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the IPAM namespace, defaults to the default namespace",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *prefixResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan prefixResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating Prefix ", plan.Prefix))
+
+	response, err := infrahub_sdk.PrefixCreate(
+		ctx,
+		*r.client,
+		plan.Prefix.ValueString(),
+		plan.Description.ValueString(),
+		plan.MemberType.ValueString(),
+		plan.IsPool.ValueBoolPointer(),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.VrfId.ValueString(),
+		plan.NamespaceId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create prefix in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraPrefixCreate.Object.PrefixFields)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *prefixResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading Prefix...")
+	var state prefixResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.Prefix(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read prefix from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The prefix was deleted outside of Terraform
+	if len(response.InfraPrefix.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.fill(response.InfraPrefix.Edges[0].Node.PrefixFields)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *prefixResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan prefixResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state prefixResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating Prefix %s", state.Prefix.ValueString()))
+
+	response, err := infrahub_sdk.PrefixUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Prefix.ValueString(),
+		plan.Description.ValueString(),
+		setDefault(plan.MemberType.ValueString(), state.MemberType.ValueString()),
+		plan.IsPool.ValueBoolPointer(),
+		setDefault(plan.Status.ValueString(), state.Status.ValueString()),
+		setDefault(plan.Role.ValueString(), state.Role.ValueString()),
+		infrahub_sdk.RelatedNode{Id: plan.VrfId.ValueString()},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update prefix in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraPrefixUpdate.Object.PrefixFields)
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *prefixResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state prefixResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.PrefixDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Prefix",
+			"Could not delete prefix, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a prefix by its node ID or by <namespace>/<prefix>.
+func (r *prefixResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+
+	if namespace, prefix, ok := splitIPImportId(req.ID); ok {
+		prefixId, err := resolvePrefixId(ctx, *r.client, "", prefix, namespace)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read prefix from Infrahub",
+				err.Error(),
+			)
+			return
+		}
+		id = prefixId
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// ModifyPlan checks the dropdown values against the schema choices.
+func (r *prefixResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "InfraPrefix", map[string]string{
+		"member_type": "member_type",
+		"status":      "status",
+		"role":        "role",
+	})...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *prefixResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *prefixResource) fill(fields infrahub_sdk.PrefixFields) {
+	r.Id = types.StringValue(fields.Id)
+	r.Prefix = types.StringValue(fields.Prefix.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.MemberType = types.StringValue(fields.Member_type.Value)
+	r.IsPool = types.BoolValue(fields.Is_pool.Value)
+	r.Status = types.StringValue(fields.Status.Value)
+	r.Role = types.StringValue(fields.Role.Value)
+	r.VrfId = types.StringNull()
+	if fields.Vrf.Node.Id != "" {
+		r.VrfId = types.StringValue(fields.Vrf.Node.Id)
+	}
+	r.NamespaceId = types.StringValue(nodeId(fields.Ip_namespace.Node))
+}
@@ -0,0 +1,356 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &relationshipResource{}
+	_ resource.ResourceWithConfigure   = &relationshipResource{}
+	_ resource.ResourceWithImportState = &relationshipResource{}
+)
+
+// NewRelationshipResource is a helper function to simplify the provider implementation.
+func NewRelationshipResource() resource.Resource {
+	return &relationshipResource{}
+}
+
+// relationshipResource is the resource implementation.
+type relationshipResource struct {
+	client           *graphql.Client
+	Id               types.String `tfsdk:"id"`
+	NodeId           types.String `tfsdk:"node_id"`
+	RelationshipName types.String `tfsdk:"relationship_name"`
+	PeerIds          types.Set    `tfsdk:"peer_ids"`
+}
+
+// Metadata returns the resource type name.
+func (r *relationshipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_relationship"
+}
+
+// Schema defines the schema for the resource.
+func (r *relationshipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages edges of a relationship of any node, e.g. adds a device to a topology owned by another " +
+			"configuration. Only the listed peers are managed, other peers of the relationship are left alone. " +
+			"Import with `<node_id>/<relationship_name>/<peer_id>[,<peer_id>...]`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "`<node_id>/<relationship_name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the node at the source of the relationship",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"relationship_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the relationship on the node, e.g. `tags` or `member_of_groups`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(namePattern, "must be the name of a relationship, e.g. `tags`"),
+				},
+			},
+			"peer_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the peers to relate to the node",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *relationshipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan relationshipResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Adding peers to %s of %s", plan.RelationshipName.ValueString(), plan.NodeId.ValueString()))
+
+	_, err := infrahub_sdk.RelationshipAdd(ctx, *r.client, plan.NodeId.ValueString(), plan.RelationshipName.ValueString(), relatedNodes(plan.PeerIds))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to add relationship peers in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(plan.NodeId.ValueString() + "/" + plan.RelationshipName.ValueString())
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data. Peers that are no
+// longer related to the node are dropped from peer_ids.
+func (r *relationshipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading relationship...")
+	var state relationshipResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, found, err := relationshipPeers(ctx, *r.client, state.NodeId.ValueString(), state.RelationshipName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read relationships from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The node was deleted outside of Terraform
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	related := map[string]bool{}
+	for _, id := range current {
+		related[id] = true
+	}
+
+	peers := []string{}
+	for _, node := range relatedNodes(state.PeerIds) {
+		if related[node.Id] {
+			peers = append(peers, node.Id)
+		}
+	}
+
+	// All edges were removed outside of Terraform
+	if len(peers) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.PeerIds = idSet(peers...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *relationshipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan relationshipResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state relationshipResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating peers of %s of %s", plan.RelationshipName.ValueString(), plan.NodeId.ValueString()))
+
+	planned := map[string]bool{}
+	for _, node := range relatedNodes(plan.PeerIds) {
+		planned[node.Id] = true
+	}
+	removed := infrahub_sdk.RelatedNodes{}
+	for _, node := range relatedNodes(state.PeerIds) {
+		if !planned[node.Id] {
+			removed = append(removed, node)
+		}
+	}
+
+	// Adding peers that are already related is a no-op in Infrahub
+	_, err := infrahub_sdk.RelationshipAdd(ctx, *r.client, plan.NodeId.ValueString(), plan.RelationshipName.ValueString(), relatedNodes(plan.PeerIds))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to add relationship peers in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+	if len(removed) > 0 {
+		_, err = infrahub_sdk.RelationshipRemove(ctx, *r.client, plan.NodeId.ValueString(), plan.RelationshipName.ValueString(), removed)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to remove relationship peers in Infrahub",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.Id = state.Id
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the managed peers from the relationship.
+func (r *relationshipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state relationshipResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.RelationshipRemove(ctx, *r.client, state.NodeId.ValueString(), state.RelationshipName.ValueString(), relatedNodes(state.PeerIds))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting relationship",
+			"Could not remove relationship peers, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports relationship edges by `<node_id>/<relationship_name>/<peer_id>[,<peer_id>...]`.
+func (r *relationshipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected <node_id>/<relationship_name>/<peer_id>[,<peer_id>...], got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0]+"/"+parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("relationship_name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("peer_ids"), idSet(strings.Split(parts[2], ",")...))...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *relationshipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// relationshipPeersPageSize is the number of peers read per query, the
+// server caps the size of a page.
+const relationshipPeersPageSize = 100
+
+// relationshipPeers returns the IDs of the peers of a relationship of a node,
+// read page by page. found is false when the node does not exist. The query is
+// built at runtime as the kind of the node is only known from the server.
+func relationshipPeers(ctx context.Context, client graphql.Client, nodeId string, name string) (peers []string, found bool, err error) {
+	var nodes struct {
+		CoreNode struct {
+			Edges []struct {
+				Node struct {
+					Typename string `json:"__typename"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"CoreNode"`
+	}
+	query := "query NodeKind($ids: [ID]) { CoreNode(ids: $ids) { edges { node { __typename } } } }"
+	if err := graphqlRequest(ctx, client, "NodeKind", query, map[string]any{"ids": []string{nodeId}}, &nodes); err != nil {
+		return nil, false, err
+	}
+	if len(nodes.CoreNode.Edges) == 0 {
+		return nil, false, nil
+	}
+	kind := nodes.CoreNode.Edges[0].Node.Typename
+	if !kindPattern.MatchString(kind) || !namePattern.MatchString(name) {
+		return nil, false, fmt.Errorf("unexpected relationship %s of kind %q", name, kind)
+	}
+
+	query = fmt.Sprintf("query RelationshipPeers($ids: [ID], $offset: Int, $limit: Int) { %s(ids: $ids) { edges { node { %s(offset: $offset, limit: $limit) { count edges { node { id } } } } } } }", kind, name)
+	peers = []string{}
+	for {
+		var response map[string]struct {
+			Edges []struct {
+				Node map[string]struct {
+					Count int `json:"count"`
+					Edges []struct {
+						Node struct {
+							Id string `json:"id"`
+						} `json:"node"`
+					} `json:"edges"`
+				} `json:"node"`
+			} `json:"edges"`
+		}
+		variables := map[string]any{"ids": []string{nodeId}, "offset": len(peers), "limit": relationshipPeersPageSize}
+		if err := graphqlRequest(ctx, client, "RelationshipPeers", query, variables, &response); err != nil {
+			return nil, false, err
+		}
+		if len(response[kind].Edges) == 0 {
+			return nil, false, nil
+		}
+
+		page := response[kind].Edges[0].Node[name]
+		for _, edge := range page.Edges {
+			peers = append(peers, edge.Node.Id)
+		}
+		// Stop on the last page, also when peers are removed while reading
+		if len(page.Edges) == 0 || len(peers) >= page.Count {
+			break
+		}
+	}
+	return peers, true, nil
+}
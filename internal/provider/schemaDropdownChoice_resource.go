@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &schemaDropdownChoiceResource{}
+	_ resource.ResourceWithConfigure   = &schemaDropdownChoiceResource{}
+	_ resource.ResourceWithImportState = &schemaDropdownChoiceResource{}
+	_ resource.ResourceWithModifyPlan  = &schemaDropdownChoiceResource{}
+)
+
+// NewSchemaDropdownChoiceResource is a helper function to simplify the provider implementation.
+func NewSchemaDropdownChoiceResource() resource.Resource {
+	return &schemaDropdownChoiceResource{}
+}
+
+// schemaDropdownChoiceResource is the resource implementation.
+type schemaDropdownChoiceResource struct {
+	client      *graphql.Client
+	schema      schemaAPI
+	choices     *schemaChoices
+	Id          types.String `tfsdk:"id"`
+	Kind        types.String `tfsdk:"kind"`
+	Attribute   types.String `tfsdk:"attribute"`
+	Name        types.String `tfsdk:"name"`
+	Label       types.String `tfsdk:"label"`
+	Color       types.String `tfsdk:"color"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Metadata returns the resource type name.
+func (r *schemaDropdownChoiceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_dropdown_choice"
+}
+
+// Schema defines the schema for the resource.
+func (r *schemaDropdownChoiceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a choice to a dropdown attribute of the schema, e.g. a custom device role. Infrahub cannot " +
+			"change a choice, so any change replaces it, which fails while nodes use the choice. " +
+			"Import with `<kind>/<attribute>/<name>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "`<kind>/<attribute>/<name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind holding the attribute, e.g. `InfraDevice`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attribute": schema.StringAttribute{
+				MarkdownDescription: "Name of the dropdown attribute, e.g. `role`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Value of the choice stored on the nodes",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label":       replacedOptionalComputedString("Label of the choice shown in the UI"),
+			"color":       replacedOptionalComputedString("Color of the choice in the UI, e.g. `#ff0000`"),
+			"description": replacedOptionalComputedString("Description of the choice"),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *schemaDropdownChoiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan schemaDropdownChoiceResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Adding %s to %s %s", plan.Name.ValueString(), plan.Kind.ValueString(), plan.Attribute.ValueString()))
+
+	response, err := infrahub_sdk.DropdownChoiceAdd(ctx, *r.client,
+		plan.Kind.ValueString(),
+		plan.Attribute.ValueString(),
+		plan.Name.ValueString(),
+		plan.Label.ValueString(),
+		plan.Color.ValueString(),
+		plan.Description.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to add dropdown choice in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	choice := response.SchemaDropdownAdd.Object
+	plan.Id = types.StringValue(strings.Join([]string{plan.Kind.ValueString(), plan.Attribute.ValueString(), plan.Name.ValueString()}, "/"))
+	plan.Label = types.StringValue(choice.Label)
+	plan.Color = types.StringValue(choice.Color)
+	plan.Description = types.StringValue(choice.Description)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *schemaDropdownChoiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading dropdown choice...")
+	var state schemaDropdownChoiceResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attribute, err := r.schema.attribute(ctx, state.Kind.ValueString(), state.Attribute.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read schema from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	var choice *schemaChoice
+	if attribute != nil {
+		for i := range attribute.Choices {
+			if attribute.Choices[i].Name == state.Name.ValueString() {
+				choice = &attribute.Choices[i]
+			}
+		}
+	}
+
+	// The choice was removed outside of Terraform
+	if choice == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Label = types.StringValue(choice.Label)
+	state.Color = types.StringValue(choice.Color)
+	state.Description = types.StringValue(choice.Description)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called, every attribute requires a replacement.
+func (r *schemaDropdownChoiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete removes the choice from the dropdown.
+func (r *schemaDropdownChoiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state schemaDropdownChoiceResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.DropdownChoiceRemove(ctx, *r.client, state.Kind.ValueString(), state.Attribute.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting dropdown choice",
+			"Could not remove dropdown choice, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ModifyPlan adds the planned choice to the choices checked by the other
+// resources, so that they accept it before it is created.
+func (r *schemaDropdownChoiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to add when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan schemaDropdownChoiceResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Kind.IsUnknown() || plan.Attribute.IsUnknown() || plan.Name.IsUnknown() {
+		r.choices.invalidate()
+		return
+	}
+	r.choices.add(plan.Kind.ValueString(), plan.Attribute.ValueString(), plan.Name.ValueString())
+}
+
+// ImportState imports a dropdown choice by `<kind>/<attribute>/<name>`.
+func (r *schemaDropdownChoiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected <kind>/<attribute>/<name>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attribute"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *schemaDropdownChoiceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.schema = providerSchemaAPI(req.ProviderData)
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &schemaEnumValueResource{}
+	_ resource.ResourceWithConfigure   = &schemaEnumValueResource{}
+	_ resource.ResourceWithImportState = &schemaEnumValueResource{}
+	_ resource.ResourceWithModifyPlan  = &schemaEnumValueResource{}
+)
+
+// NewSchemaEnumValueResource is a helper function to simplify the provider implementation.
+func NewSchemaEnumValueResource() resource.Resource {
+	return &schemaEnumValueResource{}
+}
+
+// schemaEnumValueResource is the resource implementation.
+type schemaEnumValueResource struct {
+	client    *graphql.Client
+	schema    schemaAPI
+	choices   *schemaChoices
+	Id        types.String `tfsdk:"id"`
+	Kind      types.String `tfsdk:"kind"`
+	Attribute types.String `tfsdk:"attribute"`
+	Value     types.String `tfsdk:"value"`
+}
+
+// Metadata returns the resource type name.
+func (r *schemaEnumValueResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_enum_value"
+}
+
+// Schema defines the schema for the resource.
+func (r *schemaEnumValueResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a value to the enum of an attribute of the schema. " +
+			"Import with `<kind>/<attribute>/<value>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "`<kind>/<attribute>/<value>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind holding the attribute, e.g. `InfraInterfaceL3`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attribute": schema.StringAttribute{
+				MarkdownDescription: "Name of the attribute with an enum",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value added to the enum",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *schemaEnumValueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan schemaEnumValueResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Adding %s to %s %s", plan.Value.ValueString(), plan.Kind.ValueString(), plan.Attribute.ValueString()))
+
+	_, err := infrahub_sdk.EnumValueAdd(ctx, *r.client, plan.Kind.ValueString(), plan.Attribute.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to add enum value in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(strings.Join([]string{plan.Kind.ValueString(), plan.Attribute.ValueString(), plan.Value.ValueString()}, "/"))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *schemaEnumValueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading enum value...")
+	var state schemaEnumValueResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attribute, err := r.schema.attribute(ctx, state.Kind.ValueString(), state.Attribute.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read schema from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	found := false
+	if attribute != nil {
+		for _, value := range attribute.Enum {
+			// Enums of number attributes hold numbers
+			if fmt.Sprint(value) == state.Value.ValueString() {
+				found = true
+			}
+		}
+	}
+
+	// The value was removed outside of Terraform
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is never called, every attribute requires a replacement.
+func (r *schemaEnumValueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+// Delete removes the value from the enum.
+func (r *schemaEnumValueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state schemaEnumValueResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.EnumValueRemove(ctx, *r.client, state.Kind.ValueString(), state.Attribute.ValueString(), state.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting enum value",
+			"Could not remove enum value, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ModifyPlan adds the planned value to the choices checked by the other
+// resources, so that they accept it before it is created.
+func (r *schemaEnumValueResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to add when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan schemaEnumValueResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Kind.IsUnknown() || plan.Attribute.IsUnknown() || plan.Value.IsUnknown() {
+		r.choices.invalidate()
+		return
+	}
+	r.choices.add(plan.Kind.ValueString(), plan.Attribute.ValueString(), plan.Value.ValueString())
+}
+
+// ImportState imports an enum value by `<kind>/<attribute>/<value>`.
+func (r *schemaEnumValueResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected <kind>/<attribute>/<value>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attribute"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("value"), parts[2])...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *schemaEnumValueResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.schema = providerSchemaAPI(req.ProviderData)
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// schemaAPI reads and loads the schema of the server through its REST API,
+// which has the dropdown choices and enum values missing from the GraphQL
+// schema.
+type schemaAPI struct {
+	client *http.Client
+	server string
+}
+
+// schemaNode is the part of a node schema used by the provider.
+type schemaNode struct {
+	Kind          string               `json:"kind"`
+	Attributes    []schemaAttribute    `json:"attributes"`
+	Relationships []schemaRelationship `json:"relationships"`
+}
+
+// schemaRelationship is the part of a relationship schema used by the
+// provider.
+type schemaRelationship struct {
+	Name        string `json:"name"`
+	Cardinality string `json:"cardinality"`
+}
+
+// schemaAttribute is the part of an attribute schema holding its allowed
+// values.
+type schemaAttribute struct {
+	Name    string         `json:"name"`
+	Choices []schemaChoice `json:"choices"`
+	Enum    []any          `json:"enum"`
+}
+
+// schemaChoice is a choice of a dropdown attribute.
+type schemaChoice struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// providerSchemaAPI returns the schema API of the provider from the provider
+// data handed to Configure.
+func providerSchemaAPI(providerData any) schemaAPI {
+	if client, ok := providerData.(*infrahubClient); ok {
+		return client.schema
+	}
+	return schemaAPI{}
+}
+
+// nodes returns the schema of every node kind.
+func (s schemaAPI) nodes(ctx context.Context) ([]schemaNode, error) {
+	var schema struct {
+		Nodes []schemaNode `json:"nodes"`
+	}
+	if _, err := s.get(ctx, "/api/schema", &schema); err != nil {
+		return nil, err
+	}
+	return schema.Nodes, nil
+}
+
+// node returns the schema of a node kind, nil when the kind does not exist.
+func (s schemaAPI) node(ctx context.Context, kind string) (*schemaNode, error) {
+	var node schemaNode
+	found, err := s.get(ctx, "/api/schema/"+url.PathEscape(kind), &node)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// attribute returns the schema of an attribute of a kind, nil when the kind
+// or the attribute does not exist.
+func (s schemaAPI) attribute(ctx context.Context, kind string, name string) (*schemaAttribute, error) {
+	node, err := s.node(ctx, kind)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	for _, attribute := range node.Attributes {
+		if attribute.Name == name {
+			return &attribute, nil
+		}
+	}
+	return nil, nil
+}
+
+// schemaSummary holds the hash of the schema of a branch and of each kind.
+type schemaSummary struct {
+	Main     string            `json:"main"`
+	Nodes    map[string]string `json:"nodes"`
+	Generics map[string]string `json:"generics"`
+}
+
+// summary returns the hashes of the schema of a branch, the default branch
+// when branch is empty.
+func (s schemaAPI) summary(ctx context.Context, branch string) (schemaSummary, error) {
+	var summary schemaSummary
+	_, err := s.get(ctx, "/api/schema/summary"+branchQuery(branch), &summary)
+	return summary, err
+}
+
+// check validates schema documents against the schema of a branch without
+// loading them, returning the reasons the server rejects them.
+func (s schemaAPI) check(ctx context.Context, branch string, documents []any) error {
+	resp, err := s.do(ctx, http.MethodPost, "/api/schema/check"+branchQuery(branch), map[string]any{"schemas": documents})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// load loads schema documents into a branch and returns the resulting
+// schema hash.
+func (s schemaAPI) load(ctx context.Context, branch string, documents []any) (string, error) {
+	resp, err := s.do(ctx, http.MethodPost, "/api/schema/load"+branchQuery(branch), map[string]any{"schemas": documents})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", responseError(resp)
+	}
+	var result struct {
+		Hash string `json:"hash"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("unable to decode the response of the schema load: %w", err)
+	}
+	return result.Hash, nil
+}
+
+// get decodes the response of the server to a GET request of path into v,
+// found is false when the server answers 404.
+func (s schemaAPI) get(ctx context.Context, path string, v any) (found bool, err error) {
+	resp, err := s.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, responseError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("unable to decode the response of GET %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// do sends a request to the server with body encoded as JSON unless it is nil.
+func (s schemaAPI) do(ctx context.Context, method string, path string, body any) (*http.Response, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("the provider is not configured")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("http://%s:8000%s", s.server, path), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return s.client.Do(req)
+}
+
+// responseError returns the errors reported by the server in a failed
+// response, or its status and body when they cannot be decoded.
+func responseError(resp *http.Response) error {
+	data, _ := io.ReadAll(resp.Body)
+	var body struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(data, &body) == nil && len(body.Errors) > 0 {
+		messages := []string{}
+		for _, e := range body.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("%s %s: %s", resp.Request.Method, resp.Request.URL.Path, strings.Join(messages, "; "))
+	}
+	return fmt.Errorf("%s %s returned %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status, strings.TrimSpace(string(data)))
+}
+
+// branchQuery returns the query string selecting a branch, empty for the
+// default branch.
+func branchQuery(branch string) string {
+	if branch == "" {
+		return ""
+	}
+	return "?branch=" + url.QueryEscape(branch)
+}
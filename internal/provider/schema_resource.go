@@ -0,0 +1,359 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &schemaResource{}
+	_ resource.ResourceWithConfigure  = &schemaResource{}
+	_ resource.ResourceWithModifyPlan = &schemaResource{}
+)
+
+// NewSchemaResource is a helper function to simplify the provider implementation.
+func NewSchemaResource() resource.Resource {
+	return &schemaResource{}
+}
+
+// schemaResource is the resource implementation.
+type schemaResource struct {
+	schema   schemaAPI
+	choices  *schemaChoices
+	Id       types.String `tfsdk:"id"`
+	Branch   types.String `tfsdk:"branch"`
+	Schemas  types.List   `tfsdk:"schemas"`
+	Hash     types.String `tfsdk:"hash"`
+	Nodes    types.Map    `tfsdk:"nodes"`
+	Generics types.Map    `tfsdk:"generics"`
+}
+
+// Metadata returns the resource type name.
+func (r *schemaResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+}
+
+// Schema defines the schema for the resource.
+func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Loads schema documents into Infrahub. The documents are checked against the schema of the " +
+			"branch at plan time, so incompatible changes fail the plan. Infrahub cannot unload a schema, destroying the " +
+			"resource leaves the nodes and generics in place; load them with `state: absent` to remove them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Hash of the schema after the documents were first loaded",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "Branch to load the schema into, the default branch when not set",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schemas": schema.ListAttribute{
+				MarkdownDescription: "Schema documents in YAML or JSON, e.g. `file(\"schemas/network.yml\")`",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"hash": schema.StringAttribute{
+				MarkdownDescription: "Hash of the schema of the branch",
+				Computed:            true,
+			},
+			"nodes": schema.MapAttribute{
+				MarkdownDescription: "Hash of each node kind defined or extended by the documents, changes made outside of " +
+					"Terraform show up as a different hash",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"generics": schema.MapAttribute{
+				MarkdownDescription: "Hash of each generic kind defined by the documents",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *schemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan schemaResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Loading %d schema documents", len(plan.Schemas.Elements())))
+
+	resp.Diagnostics.Append(r.load(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Id = plan.Hash
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *schemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading schema...")
+	var state schemaResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documents, diags := schemaDocuments(ctx, state.Schemas)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	summary, err := r.schema.summary(ctx, state.Branch.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read schema from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// None of the kinds are in the schema anymore, e.g. the branch was reset
+	if !state.fill(summary, documents) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *schemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan schemaResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Loading %d schema documents", len(plan.Schemas.Elements())))
+
+	resp.Diagnostics.Append(r.load(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete only removes the resource from the state, Infrahub cannot unload a
+// schema.
+func (r *schemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Schema left in Infrahub",
+		"Infrahub cannot unload schema documents, the nodes and generics they define stay in the schema. "+
+			"Load them with `state: absent` to remove them.",
+	)
+}
+
+// ModifyPlan fails the plan when the server rejects changed schema documents.
+// Changed documents stop the dropdown choices from being checked for the run.
+func (r *schemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.schema.client == nil {
+		return
+	}
+
+	var plan schemaResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state schemaResource
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.Schemas.Equal(plan.Schemas) {
+			return
+		}
+	}
+
+	// The documents may change the dropdown choices checked by other resources
+	r.choices.invalidate()
+
+	if plan.Schemas.IsUnknown() {
+		return
+	}
+	for _, document := range plan.Schemas.Elements() {
+		if document.IsUnknown() {
+			return
+		}
+	}
+
+	documents, diags := schemaDocuments(ctx, plan.Schemas)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.schema.check(ctx, plan.Branch.ValueString(), documents); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schemas"),
+			"Incompatible schema",
+			err.Error(),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *schemaResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	if _, ok := req.ProviderData.(graphql.Client); !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.schema = providerSchemaAPI(req.ProviderData)
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// load loads the schema documents of the plan and reads back the hashes.
+func (r *schemaResource) load(ctx context.Context, plan *schemaResource) diag.Diagnostics {
+	documents, diags := schemaDocuments(ctx, plan.Schemas)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := r.schema.load(ctx, plan.Branch.ValueString(), documents); err != nil {
+		diags.AddError("Unable to load schema in Infrahub", err.Error())
+		return diags
+	}
+
+	summary, err := r.schema.summary(ctx, plan.Branch.ValueString())
+	if err != nil {
+		diags.AddError("Unable to read schema from Infrahub", err.Error())
+		return diags
+	}
+	plan.fill(summary, documents)
+	return diags
+}
+
+// fill copies the hashes of the kinds of the documents into the resource
+// model, returning false when none of them is in the schema.
+func (r *schemaResource) fill(summary schemaSummary, documents []any) bool {
+	nodes, generics := schemaKinds(documents)
+	nodeHashes, foundNodes := kindHashes(summary.Nodes, nodes)
+	genericHashes, foundGenerics := kindHashes(summary.Generics, generics)
+
+	r.Hash = types.StringValue(summary.Main)
+	r.Nodes = nodeHashes
+	r.Generics = genericHashes
+	return foundNodes+foundGenerics > 0 || len(nodes)+len(generics) == 0
+}
+
+// schemaDocuments decodes the YAML or JSON schema documents of the resource.
+func schemaDocuments(ctx context.Context, schemas types.List) ([]any, diag.Diagnostics) {
+	var texts []string
+	diags := schemas.ElementsAs(ctx, &texts, false)
+
+	documents := []any{}
+	for i, text := range texts {
+		var document map[string]any
+		if err := yaml.Unmarshal([]byte(text), &document); err != nil {
+			diags.AddAttributeError(path.Root("schemas").AtListIndex(i), "Invalid schema document", err.Error())
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, diags
+}
+
+// schemaKinds returns the node kinds defined or extended by the documents and
+// the generic kinds they define.
+func schemaKinds(documents []any) (nodes []string, generics []string) {
+	for _, document := range documents {
+		document, _ := document.(map[string]any)
+		nodes = append(nodes, definedKinds(document["nodes"])...)
+		generics = append(generics, definedKinds(document["generics"])...)
+		if extensions, ok := document["extensions"].(map[string]any); ok {
+			nodes = append(nodes, definedKinds(extensions["nodes"])...)
+		}
+	}
+	return nodes, generics
+}
+
+// definedKinds returns the kinds of a list of node or generic definitions,
+// given as `kind` or as `namespace` and `name`.
+func definedKinds(definitions any) []string {
+	kinds := []string{}
+	items, _ := definitions.([]any)
+	for _, item := range items {
+		definition, _ := item.(map[string]any)
+		if kind, ok := definition["kind"].(string); ok {
+			kinds = append(kinds, kind)
+			continue
+		}
+		namespace, _ := definition["namespace"].(string)
+		name, _ := definition["name"].(string)
+		kinds = append(kinds, namespace+name)
+	}
+	return kinds
+}
+
+// kindHashes returns the hashes of the kinds present in the schema and how
+// many of them were found.
+func kindHashes(hashes map[string]string, kinds []string) (types.Map, int) {
+	elements := map[string]attr.Value{}
+	for _, kind := range kinds {
+		if hash, ok := hashes[kind]; ok {
+			elements[kind] = types.StringValue(hash)
+		}
+	}
+	return types.MapValueMust(types.StringType, elements), len(elements)
+}
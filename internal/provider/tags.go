@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Taggable resources expose the tags set in their configuration as `tags`
+// and every tag of the node, including the provider `default_tags`, as
+// `tags_all`. The default tags are merged in on create and update and left
+// out of `tags` when reading the node back.
+
+// tagsAttribute returns the schema of the `tags` attribute.
+func tagsAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "IDs of the tags of the object, the provider `default_tags` are added to them",
+		ElementType:         types.StringType,
+		Computed:            true,
+		Optional:            true,
+		// Removing the tags from the configuration clears them
+		Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+	}
+}
+
+// tagsAllAttribute returns the schema of the `tags_all` attribute.
+func tagsAllAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "IDs of all tags of the object, including the provider `default_tags`",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+}
+
+// providerDefaultTags returns the provider `default_tags` from the provider data
+// handed to Configure.
+func providerDefaultTags(providerData any) []string {
+	if client, ok := providerData.(*infrahubClient); ok {
+		return client.defaultTags
+	}
+	return nil
+}
+
+// mergeTags returns the tag IDs of a resource together with the default tags.
+func mergeTags(tags types.Set, defaults []string) types.Set {
+	ids := append([]string{}, defaults...)
+	for _, id := range tags.Elements() {
+		if id, ok := id.(types.String); ok {
+			ids = append(ids, id.ValueString())
+		}
+	}
+	return idSet(ids...)
+}
+
+// resourceTags returns the tags of a node that belong in `tags`: default tags
+// are left out unless the prior value already held them.
+func resourceTags(all []string, prior types.Set, defaults []string) types.Set {
+	kept := map[string]bool{}
+	for _, id := range prior.Elements() {
+		if id, ok := id.(types.String); ok {
+			kept[id.ValueString()] = true
+		}
+	}
+
+	ids := []string{}
+	for _, id := range all {
+		if !slices.Contains(defaults, id) || kept[id] {
+			ids = append(ids, id)
+		}
+	}
+	return idSet(ids...)
+}
+
+// relatedNodeInputs converts a set of node IDs into the input of a
+// relationship of cardinality many.
+func relatedNodeInputs(ids types.Set) []infrahub_sdk.RelatedNodeInput {
+	nodes := []infrahub_sdk.RelatedNodeInput{}
+	for _, node := range relatedNodes(ids) {
+		nodes = append(nodes, infrahub_sdk.RelatedNodeInput{Id: node.Id})
+	}
+	return nodes
+}
+
+// planTagsAll sets `tags_all` in the plan of a taggable resource to its tags
+// merged with the default tags, so changing the default tags shows up as a
+// diff on every resource.
+func planTagsAll(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, defaults []string) diag.Diagnostics {
+	var tags types.Set
+	diags := req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)
+	if diags.HasError() || tags.IsUnknown() {
+		return diags
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), mergeTags(tags, defaults))...)
+	return diags
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.String = cidrValidator{}
+	_ validator.Int64  = asnValidator{}
+)
+
+// cidrValidator checks that a value is an address with a prefix length, e.g.
+// 10.0.0.1/24. With network set the host bits must be zero, e.g. 10.0.0.0/24.
+type cidrValidator struct {
+	network bool
+}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	if v.network {
+		return "value must be a network in CIDR notation, e.g. 10.0.0.0/24"
+	}
+	return "value must be an address in CIDR notation, e.g. 10.0.0.1/24"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	prefix, err := netip.ParsePrefix(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("%s, got %q: %s", v.Description(ctx), req.ConfigValue.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if v.network && prefix.Masked() != prefix {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("%s, got %q which has host bits set, did you mean %q?", v.Description(ctx), req.ConfigValue.ValueString(), prefix.Masked().String()),
+		)
+	}
+}
+
+// asnValidator checks that a value is a usable 2-byte (1-65535) or 4-byte
+// (65536-4294967294) AS number. AS_TRANS (23456) is reserved for 4-byte AS
+// number migration and rejected.
+type asnValidator struct{}
+
+func (v asnValidator) Description(_ context.Context) string {
+	return "value must be a 2-byte AS number between 1 and 65535 or a 4-byte AS number between 65536 and 4294967294, except 23456"
+}
+
+func (v asnValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v asnValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	asn := req.ConfigValue.ValueInt64()
+	if asn < 1 || asn > 4294967294 || asn == 23456 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid AS number",
+			fmt.Sprintf("%s, got %d", v.Description(ctx), asn),
+		)
+	}
+}
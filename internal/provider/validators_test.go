@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCidrValidator(t *testing.T) {
+	tests := []struct {
+		value   types.String
+		network bool
+		valid   bool
+	}{
+		{value: types.StringValue("10.0.0.1/24"), valid: true},
+		{value: types.StringValue("10.0.0.0/24"), network: true, valid: true},
+		{value: types.StringValue("2001:db8::1/64"), valid: true},
+		{value: types.StringValue("2001:db8::/64"), network: true, valid: true},
+		{value: types.StringValue("10.0.0.1/24"), network: true},
+		{value: types.StringValue("2001:db8::1/64"), network: true},
+		{value: types.StringValue("10.0.0.1")},
+		{value: types.StringValue("10.0.0.1/33")},
+		{value: types.StringValue("not-an-address/24")},
+		{value: types.StringNull(), network: true, valid: true},
+		{value: types.StringUnknown(), network: true, valid: true},
+	}
+	for _, test := range tests {
+		req := validator.StringRequest{Path: path.Root("address"), ConfigValue: test.value}
+		resp := validator.StringResponse{}
+		cidrValidator{network: test.network}.ValidateString(context.Background(), req, &resp)
+		if valid := !resp.Diagnostics.HasError(); valid != test.valid {
+			t.Errorf("cidrValidator{network: %t} on %s: valid = %t, want %t", test.network, test.value, valid, test.valid)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// int64Value converts a NumberAttribute value, an unset number becomes null.
+func int64Value(number json.Number) (types.Int64, error) {
+	if number == "" {
+		return types.Int64Null(), nil
+	}
+	value, err := number.Int64()
+	if err != nil {
+		return types.Int64Null(), err
+	}
+	return types.Int64Value(value), nil
+}
+
+// int64String formats value as a BigInt variable, or "" if it is null or unknown.
+func int64String(value types.Int64) string {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+	return strconv.FormatInt(value.ValueInt64(), 10)
+}
+
+// idSet returns a set of the given node IDs, skipping empty ones.
+func idSet(ids ...string) types.Set {
+	elements := []attr.Value{}
+	for _, id := range ids {
+		if id != "" {
+			elements = append(elements, types.StringValue(id))
+		}
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// firstId returns an element of a set of node IDs, or "" if the set is empty,
+// null or unknown.
+func firstId(ids types.Set) string {
+	for _, id := range ids.Elements() {
+		if id, ok := id.(types.String); ok {
+			return id.ValueString()
+		}
+	}
+	return ""
+}
+
+// relatedNodes converts a set of node IDs into the peers of a relationship of
+// cardinality many. A null or unknown set gives an empty list.
+func relatedNodes(ids types.Set) infrahub_sdk.RelatedNodes {
+	nodes := infrahub_sdk.RelatedNodes{}
+	for _, id := range ids.Elements() {
+		if id, ok := id.(types.String); ok {
+			nodes = append(nodes, infrahub_sdk.RelatedNode{Id: id.ValueString()})
+		}
+	}
+	return nodes
+}
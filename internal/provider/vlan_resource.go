@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &vlanResource{}
+	_ resource.ResourceWithConfigure   = &vlanResource{}
+	_ resource.ResourceWithImportState = &vlanResource{}
+	_ resource.ResourceWithModifyPlan  = &vlanResource{}
+)
+
+// NewVLANResource is a helper function to simplify the provider implementation.
+func NewVLANResource() resource.Resource {
+	return &vlanResource{}
+}
+
+// vlanResource is the resource implementation.
+type vlanResource struct {
+	client           *graphql.Client
+	choices          *schemaChoices
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	VlanId           types.Int64  `tfsdk:"vlan_id"`
+	Status           types.String `tfsdk:"status"`
+	Role             types.String `tfsdk:"role"`
+	LocationId       types.String `tfsdk:"location_id"`
+	NetworkServiceId types.String `tfsdk:"network_service_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *vlanResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vlan"
+}
+
+// Schema defines the schema for the resource.
+func (r *vlanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraVLAN`. A `vlan_id` already used by another VLAN of the same location is rejected at plan time.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vlan_id": schema.Int64Attribute{
+				MarkdownDescription: "802.1Q VLAN ID between 1 and 4094",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+			},
+			"status": schema.StringAttribute{
+				Required: true,
+			},
+			"role": schema.StringAttribute{
+				Required: true,
+			},
+			"location_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the location the VLAN is defined in",
+				Required:            true,
+			},
+			"network_service_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the network service using the VLAN",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan checks the dropdown values against the schema choices and
+// rejects a vlan_id that is already taken by another VLAN of the same location.
+func (r *vlanResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed or the provider isn't configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.choices.check(ctx, req.Plan, "InfraVLAN", map[string]string{
+		"status": "status",
+		"role":   "role",
+	})...)
+
+	var plan vlanResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.VlanId.IsUnknown() || plan.LocationId.IsUnknown() {
+		return
+	}
+
+	response, err := infrahub_sdk.VLANs(
+		ctx,
+		*r.client,
+		strconv.FormatInt(plan.VlanId.ValueInt64(), 10),
+		[]string{plan.LocationId.ValueString()},
+		"",
+		"",
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read VLANs from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, edge := range response.InfraVLAN.Edges {
+		if edge.Node.Id == plan.Id.ValueString() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("vlan_id"),
+			"Duplicate VLAN ID",
+			fmt.Sprintf("VLAN ID %d is already used by VLAN %s (%s) in location %s.", plan.VlanId.ValueInt64(), edge.Node.Name.Value, edge.Node.Id, plan.LocationId.ValueString()),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *vlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan vlanResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating VLAN ", plan.Name))
+
+	response, err := infrahub_sdk.VLANCreate(
+		ctx,
+		*r.client,
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		strconv.FormatInt(plan.VlanId.ValueInt64(), 10),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.LocationId.ValueString(),
+		plan.NetworkServiceId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create vlan in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraVLANCreate.Object.VLANFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *vlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading VLAN...")
+	var state vlanResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.VLAN(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read vlan from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The VLAN was deleted outside of Terraform
+	if len(response.InfraVLAN.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.fill(response.InfraVLAN.Edges[0].Node.VLANFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *vlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan vlanResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state vlanResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating VLAN %s", state.Name.ValueString()))
+
+	response, err := infrahub_sdk.VLANUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		plan.Description.ValueString(),
+		strconv.FormatInt(plan.VlanId.ValueInt64(), 10),
+		plan.Status.ValueString(),
+		plan.Role.ValueString(),
+		plan.LocationId.ValueString(),
+		setDefault(plan.NetworkServiceId.ValueString(), state.NetworkServiceId.ValueString()),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update vlan in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.fill(response.InfraVLANUpdate.Object.VLANFields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *vlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state vlanResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.VLANDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting VLAN",
+			"Could not delete vlan, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a VLAN by its node ID.
+func (r *vlanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vlanResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+	r.choices = providerSchemaChoices(req.ProviderData)
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *vlanResource) fill(fields infrahub_sdk.VLANFields) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	vlanId, err := fields.Vlan_id.Value.Int64()
+	if err != nil {
+		diags.AddError(
+			"Unable to parse vlan_id returned by Infrahub",
+			err.Error(),
+		)
+		return diags
+	}
+
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.Description = types.StringValue(fields.Description.Value)
+	r.VlanId = types.Int64Value(vlanId)
+	r.Status = types.StringValue(fields.Status.Value)
+	r.Role = types.StringValue(fields.Role.Value)
+	r.LocationId = types.StringValue(nodeId(fields.Location.Node))
+	r.NetworkServiceId = types.StringValue(nodeId(fields.Network_service.Node))
+	return diags
+}
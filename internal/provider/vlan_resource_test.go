@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+func TestVLANResourceFill(t *testing.T) {
+	tests := []struct {
+		name       string
+		vlanId     json.Number
+		location   infrahub_sdk.VLANFieldsLocationNestedEdgedLocationGenericNodeLocationGeneric
+		wantVlanId int64
+		wantError  bool
+		locationId string
+	}{
+		{
+			name:       "with location",
+			vlanId:     "100",
+			location:   &infrahub_sdk.VLANFieldsLocationNestedEdgedLocationGenericNodeLocationBuilding{Id: "building-1"},
+			wantVlanId: 100,
+			locationId: "building-1",
+		},
+		{
+			name:       "without location",
+			vlanId:     "4094",
+			wantVlanId: 4094,
+		},
+		{
+			name:      "invalid vlan_id",
+			vlanId:    "10.5",
+			wantError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields := infrahub_sdk.VLANFields{Id: "vlan-1"}
+			fields.Vlan_id.Value = test.vlanId
+			fields.Location.Node = test.location
+
+			var r vlanResource
+			diags := r.fill(fields)
+			if diags.HasError() != test.wantError {
+				t.Fatalf("fill() errors = %v, want error %t", diags, test.wantError)
+			}
+			if test.wantError {
+				return
+			}
+			if r.VlanId.ValueInt64() != test.wantVlanId {
+				t.Errorf("vlan_id = %d, want %d", r.VlanId.ValueInt64(), test.wantVlanId)
+			}
+			if r.LocationId.ValueString() != test.locationId {
+				t.Errorf("location_id = %q, want %q", r.LocationId.ValueString(), test.locationId)
+			}
+			if r.NetworkServiceId.ValueString() != "" {
+				t.Errorf("network_service_id = %q, want empty", r.NetworkServiceId.ValueString())
+			}
+		})
+	}
+}
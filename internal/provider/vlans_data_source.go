@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vlansDataSource{}
+	_ datasource.DataSourceWithConfigure = &vlansDataSource{}
+)
+
+// NewVLANsDataSource is a helper function to simplify the provider implementation.
+func NewVLANsDataSource() datasource.DataSource {
+	return &vlansDataSource{}
+}
+
+type vlansDataSource struct {
+	client     *graphql.Client
+	LocationId types.String     `tfsdk:"location_id"`
+	Role       types.String     `tfsdk:"role"`
+	Status     types.String     `tfsdk:"status"`
+	Vlans      []vlansVLANModel `tfsdk:"vlans"`
+}
+
+type vlansVLANModel struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	VlanId           types.Int64  `tfsdk:"vlan_id"`
+	Status           types.String `tfsdk:"status"`
+	Role             types.String `tfsdk:"role"`
+	LocationId       types.String `tfsdk:"location_id"`
+	NetworkServiceId types.String `tfsdk:"network_service_id"`
+}
+
+func (d *vlansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vlans"
+}
+
+func (d *vlansDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists `InfraVLAN` objects, optionally filtered by location, role and status.",
+		Attributes: map[string]schema.Attribute{
+			"location_id": schema.StringAttribute{
+				MarkdownDescription: "Only return VLANs of this location",
+				Optional:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Only return VLANs with this role",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return VLANs with this status",
+				Optional:            true,
+			},
+			"vlans": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"vlan_id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"role": schema.StringAttribute{
+							Computed: true,
+						},
+						"location_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"network_service_id": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vlansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading VLANs data...")
+	var config vlansDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var locationIds []string
+	if config.LocationId.ValueString() != "" {
+		locationIds = []string{config.LocationId.ValueString()}
+	}
+
+	response, err := infrahub_sdk.VLANs(ctx, *d.client, "", locationIds, config.Role.ValueString(), config.Status.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read VLANs from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	state := vlansDataSource{
+		LocationId: config.LocationId,
+		Role:       config.Role,
+		Status:     config.Status,
+		Vlans:      []vlansVLANModel{},
+	}
+
+	for _, edge := range response.InfraVLAN.Edges {
+		var vlan vlanResource
+		resp.Diagnostics.Append(vlan.fill(edge.Node.VLANFields)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Vlans = append(state.Vlans, vlansVLANModel{
+			Id:               vlan.Id,
+			Name:             vlan.Name,
+			Description:      vlan.Description,
+			VlanId:           vlan.VlanId,
+			Status:           vlan.Status,
+			Role:             vlan.Role,
+			LocationId:       vlan.LocationId,
+			NetworkServiceId: vlan.NetworkServiceId,
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vlansDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
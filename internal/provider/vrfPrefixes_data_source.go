@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vrfPrefixesDataSource{}
+	_ datasource.DataSourceWithConfigure = &vrfPrefixesDataSource{}
+)
+
+// NewVRFPrefixesDataSource is a helper function to simplify the provider implementation.
+func NewVRFPrefixesDataSource() datasource.DataSource {
+	return &vrfPrefixesDataSource{}
+}
+
+type vrfPrefixesDataSource struct {
+	client   *graphql.Client
+	VrfId    types.String             `tfsdk:"vrf_id"`
+	Prefixes []vrfPrefixesPrefixModel `tfsdk:"prefixes"`
+}
+
+type vrfPrefixesPrefixModel struct {
+	Id          types.String `tfsdk:"id"`
+	Prefix      types.String `tfsdk:"prefix"`
+	Description types.String `tfsdk:"description"`
+	MemberType  types.String `tfsdk:"member_type"`
+	IsPool      types.Bool   `tfsdk:"is_pool"`
+	Status      types.String `tfsdk:"status"`
+	Role        types.String `tfsdk:"role"`
+	NamespaceId types.String `tfsdk:"namespace_id"`
+}
+
+func (d *vrfPrefixesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vrf_prefixes"
+}
+
+func (d *vrfPrefixesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the prefixes attached to an `InfraVRF`.",
+		Attributes: map[string]schema.Attribute{
+			"vrf_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the VRF",
+				Required:            true,
+			},
+			"prefixes": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"prefix": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"member_type": schema.StringAttribute{
+							Computed: true,
+						},
+						"is_pool": schema.BoolAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"role": schema.StringAttribute{
+							Computed: true,
+						},
+						"namespace_id": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vrfPrefixesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading VRF prefixes data...")
+	var config vrfPrefixesDataSource
+
+	// Read configuration into config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.VRFPrefixes(ctx, *d.client, config.VrfId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read VRF prefixes from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	state := vrfPrefixesDataSource{
+		VrfId:    config.VrfId,
+		Prefixes: []vrfPrefixesPrefixModel{},
+	}
+
+	for _, edge := range response.InfraPrefix.Edges {
+		var prefix prefixResource
+		prefix.fill(edge.Node.PrefixFields)
+
+		state.Prefixes = append(state.Prefixes, vrfPrefixesPrefixModel{
+			Id:          prefix.Id,
+			Prefix:      prefix.Prefix,
+			Description: prefix.Description,
+			MemberType:  prefix.MemberType,
+			IsPool:      prefix.IsPool,
+			Status:      prefix.Status,
+			Role:        prefix.Role,
+			NamespaceId: prefix.NamespaceId,
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vrfPrefixesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = &client
+}
@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	infrahub_sdk "github.com/opsmill/infrahub-sdk-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &vrfResource{}
+	_ resource.ResourceWithConfigure   = &vrfResource{}
+	_ resource.ResourceWithImportState = &vrfResource{}
+)
+
+// NewVRFResource is a helper function to simplify the provider implementation.
+func NewVRFResource() resource.Resource {
+	return &vrfResource{}
+}
+
+// vrfResource is the resource implementation.
+type vrfResource struct {
+	client   *graphql.Client
+	Id       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	VrfRd    types.String `tfsdk:"vrf_rd"`
+	ImportRt types.Set    `tfsdk:"import_rt"`
+	ExportRt types.Set    `tfsdk:"export_rt"`
+}
+
+// Metadata returns the resource type name.
+func (r *vrfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vrf"
+}
+
+// Schema defines the schema for the resource.
+func (r *vrfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an `InfraVRF`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"vrf_rd": schema.StringAttribute{
+				MarkdownDescription: "Route distinguisher, e.g. `65000:100`",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"import_rt": schema.SetAttribute{
+				MarkdownDescription: "IDs of the route targets imported into the VRF. The Infrahub schema currently allows a single route target.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"export_rt": schema.SetAttribute{
+				MarkdownDescription: "IDs of the route targets exported from the VRF. The Infrahub schema currently allows a single route target.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *vrfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan vrfResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprint("Creating VRF ", plan.Name))
+
+	response, err := infrahub_sdk.VRFCreate(
+		ctx,
+		*r.client,
+		plan.Name.ValueString(),
+		plan.VrfRd.ValueString(),
+		firstId(plan.ImportRt),
+		firstId(plan.ExportRt),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create vrf in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraVRFCreate.Object.VRFFields)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *vrfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading VRF...")
+	var state vrfResource
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := infrahub_sdk.VRF(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read vrf from Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	// The VRF was deleted outside of Terraform
+	if len(response.InfraVRF.Edges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.fill(response.InfraVRF.Edges[0].Node.VRFFields)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *vrfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve the planned configuration values from Terraform
+	var plan vrfResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the current state
+	var state vrfResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating VRF %s", state.Name.ValueString()))
+
+	// An empty set clears the route target, an unset one keeps it
+	response, err := infrahub_sdk.VRFUpdate(
+		ctx,
+		*r.client,
+		state.Id.ValueString(),
+		plan.Name.ValueString(),
+		plan.VrfRd.ValueString(),
+		infrahub_sdk.RelatedNode{Id: firstId(plan.ImportRt)},
+		infrahub_sdk.RelatedNode{Id: firstId(plan.ExportRt)},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update vrf in Infrahub",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.fill(response.InfraVRFUpdate.Object.VRFFields)
+
+	// Set the updated state with the latest data
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *vrfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state vrfResource
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := infrahub_sdk.VRFDelete(ctx, *r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting VRF",
+			"Could not delete vrf, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a VRF by its node ID.
+func (r *vrfResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vrfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(graphql.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = &client
+}
+
+// fill copies the fields returned by Infrahub into the resource model.
+func (r *vrfResource) fill(fields infrahub_sdk.VRFFields) {
+	r.Id = types.StringValue(fields.Id)
+	r.Name = types.StringValue(fields.Name.Value)
+	r.VrfRd = types.StringValue(fields.Vrf_rd.Value)
+	r.ImportRt = idSet(fields.Import_rt.Node.Id)
+	r.ExportRt = idSet(fields.Export_rt.Node.Id)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package infrahub_sdk
+
+import "encoding/json"
+
+// RelatedNode references the peer of a relationship of cardinality one by ID.
+// Unlike the generated RelatedNodeInput it only sends the ID, and an empty ID
+// is sent as null which clears the relationship.
+type RelatedNode struct {
+	Id string `json:"id"`
+}
+
+func (r RelatedNode) MarshalJSON() ([]byte, error) {
+	if r.Id == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		Id string `json:"id"`
+	}{r.Id})
+}
+
+// RelatedNodes references the peers of a relationship of cardinality many by
+// ID. Sending it replaces all peers of the relationship.
+type RelatedNodes []RelatedNode